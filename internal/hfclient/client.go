@@ -0,0 +1,297 @@
+// Package hfclient is a typed client for the parts of the Hugging Face Hub
+// API used by the provider.
+package hfclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem the client logs to.
+const logSubsystem = "hfclient"
+
+// Config configures a Client.
+type Config struct {
+	// HTTPClient is the HTTP client used for all API requests. It is
+	// expected to authenticate requests itself.
+	HTTPClient *http.Client
+
+	// Endpoint is the base URL of the Hugging Face Hub API.
+	Endpoint string
+
+	// InferenceEndpointsEndpoint is the base URL of the Inference Endpoints
+	// API, which is served separately from the Hub.
+	InferenceEndpointsEndpoint string
+
+	// MaxErrorBodyBytes bounds how much of a response body is read into
+	// errors, and how much of a body is logged with DebugHTTP.
+	MaxErrorBodyBytes int64
+
+	// MaxRetries is how many times a request answered with a 429 or 5xx
+	// status code is retried.
+	MaxRetries int
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between
+	// two attempts. A Retry-After header sent by the API takes precedence.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// DebugHTTP logs the bodies of all requests and responses at TRACE
+	// level, with the values of secrets and tokens redacted.
+	DebugHTTP bool
+}
+
+// Client sends requests to the Hugging Face Hub API.
+type Client struct {
+	config Config
+
+	// rateLimit is the last quota reported by the API, guarded by
+	// rateLimitMu as requests are sent concurrently.
+	rateLimitMu sync.Mutex
+	rateLimit   *RateLimit
+}
+
+// New returns a client configured by config.
+func New(config Config) *Client {
+	return &Client{
+		config: config,
+	}
+}
+
+// Endpoint returns the base URL of the Hub API the client talks to.
+func (c *Client) Endpoint() string {
+	return c.config.Endpoint
+}
+
+// url builds a URL below the endpoint from the given path parts. A part may
+// itself contain slashes, as repository IDs of the form owner/name do; these
+// are kept literal while every path segment in between is escaped.
+func (c *Client) url(parts ...string) string {
+	return joinURL(c.config.Endpoint, parts...)
+}
+
+// joinURL builds a URL below base from the given path parts, as url does.
+func joinURL(base string, parts ...string) string {
+	segments := []string{strings.TrimRight(base, "/")}
+
+	for _, part := range parts {
+		for _, segment := range strings.Split(part, "/") {
+			if segment == "" {
+				continue
+			}
+			segments = append(segments, url.PathEscape(segment))
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// newRequest builds a request to url. A non-nil in is sent as the JSON body.
+func (c *Client) newRequest(ctx context.Context, method string, url string, in interface{}) (*http.Request, error) {
+	var body io.Reader
+	if in != nil {
+		reqBody, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request body: %w", err)
+		}
+		body = bytes.NewReader(reqBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// send sends req and returns the response if it has a 2xx status code. Any
+// other status code is returned as an *APIError. Requests answered with a 429
+// or 5xx status code are retried, resending the exact same request so that
+// headers such as an idempotency key are kept. Requests are delayed when the
+// remaining rate limit quota runs low. The caller is responsible for closing
+// the body of the returned response.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if err := c.throttle(ctx); err != nil {
+			return nil, err
+		}
+
+		if c.config.DebugHTTP {
+			traceRequest(ctx, req, c.config.MaxErrorBodyBytes)
+		}
+
+		resp, err := c.config.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		c.recordRateLimit(ctx, resp)
+
+		tflog.SubsystemDebug(ctx, logSubsystem, "API request", map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"status_code": resp.StatusCode,
+		})
+
+		if c.config.DebugHTTP {
+			traceResponse(ctx, resp, c.config.MaxErrorBodyBytes)
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			return resp, nil
+		}
+
+		if !retryable(resp.StatusCode) || attempt >= c.config.MaxRetries {
+			defer resp.Body.Close()
+
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, c.config.MaxErrorBodyBytes))
+			return nil, newAPIError(req, resp.StatusCode, respBody)
+		}
+
+		wait := c.backoff(attempt, resp)
+		resp.Body.Close()
+
+		tflog.SubsystemDebug(ctx, logSubsystem, "Retrying API request", map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"wait":        wait.String(),
+			"attempt":     attempt + 1,
+			"max_retries": c.config.MaxRetries,
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryable reports whether a request answered with statusCode may succeed
+// when it is sent again.
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// backoff returns how long to wait before the attempt following attempt,
+// which was answered with resp.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			if wait := time.Until(at); wait > 0 {
+				return wait
+			}
+			return 0
+		}
+	}
+
+	wait := c.config.RetryWaitMin
+	for i := 0; i < attempt && wait < c.config.RetryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > c.config.RetryWaitMax {
+		wait = c.config.RetryWaitMax
+	}
+
+	return wait
+}
+
+// doRequest sends req and decodes the JSON response into out, unless out is
+// nil.
+func (c *Client) doRequest(req *http.Request, out interface{}) error {
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response body: %w", err)
+	}
+
+	return nil
+}
+
+// do sends a request with in as the JSON body, unless it is nil, and decodes
+// the JSON response into out, unless it is nil.
+func (c *Client) do(ctx context.Context, method string, url string, in interface{}, out interface{}) error {
+	req, err := c.newRequest(ctx, method, url, in)
+	if err != nil {
+		return err
+	}
+
+	return c.doRequest(req, out)
+}
+
+// linkNextRegexp matches the URL of the next page in a Link header.
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// list sends a GET request to url and decodes the JSON array it returns,
+// following the Link headers of paginated responses until limit items were
+// read. A limit of zero reads every page.
+func list[T any](ctx context.Context, c *Client, url string, limit int) ([]T, error) {
+	var items []T
+
+	for url != "" {
+		req, err := c.newRequest(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []T
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding response body: %w", err)
+		}
+
+		items = append(items, page...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+
+		url = ""
+		if match := linkNextRegexp.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			url = match[1]
+		}
+	}
+
+	return items, nil
+}
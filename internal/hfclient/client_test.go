@@ -0,0 +1,69 @@
+package hfclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client sending its requests to handler, retrying
+// failed requests twice without waiting.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return New(Config{
+		HTTPClient:        server.Client(),
+		Endpoint:          server.URL,
+		MaxErrorBodyBytes: 4096,
+		MaxRetries:        2,
+		RetryWaitMin:      time.Millisecond,
+		RetryWaitMax:      time.Millisecond,
+	})
+}
+
+func TestClientURL(t *testing.T) {
+	tests := map[string]struct {
+		endpoint string
+		parts    []string
+		expected string
+	}{
+		"owner/name": {
+			endpoint: "https://huggingface.co",
+			parts:    []string{"api", "spaces", "acme/demo", "runtime"},
+			expected: "https://huggingface.co/api/spaces/acme/demo/runtime",
+		},
+		"trailing slash": {
+			endpoint: "https://hub.example.com/",
+			parts:    []string{"api", "spaces", "acme/demo"},
+			expected: "https://hub.example.com/api/spaces/acme/demo",
+		},
+		"base path": {
+			endpoint: "https://proxy.example.com/hf/",
+			parts:    []string{"api", "whoami-v2"},
+			expected: "https://proxy.example.com/hf/api/whoami-v2",
+		},
+		"empty segments": {
+			endpoint: "https://huggingface.co",
+			parts:    []string{"/api/", "spaces", "acme//demo/"},
+			expected: "https://huggingface.co/api/spaces/acme/demo",
+		},
+		"escaped segments": {
+			endpoint: "https://huggingface.co",
+			parts:    []string{"api", "spaces", "acme/demo", "resolve", "main", "docs/read me?.md"},
+			expected: "https://huggingface.co/api/spaces/acme/demo/resolve/main/docs/read%20me%3F.md",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := New(Config{Endpoint: test.endpoint})
+			if actual := client.url(test.parts...); actual != test.expected {
+				t.Errorf("url(%q) against %q = %q, expected %q", test.parts, test.endpoint, actual, test.expected)
+			}
+		})
+	}
+}
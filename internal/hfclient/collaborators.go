@@ -0,0 +1,34 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Collaborator describes a user or team granted access to a repository.
+type Collaborator struct {
+	// Type is user or team.
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Role is read, write or admin.
+	Role string `json:"role"`
+}
+
+// ListCollaborators lists the users and teams granted access to the
+// repository repoID.
+func (c *Client) ListCollaborators(ctx context.Context, repoType RepoType, repoID string) ([]Collaborator, error) {
+	return list[Collaborator](ctx, c, c.url("api", repoType.apiPath(), repoID, "collaborators"), 0)
+}
+
+// SetCollaborator grants the user or team in access to the repository repoID
+// with its role, replacing the role it had before.
+func (c *Client) SetCollaborator(ctx context.Context, repoType RepoType, repoID string, in Collaborator) error {
+	return c.do(ctx, http.MethodPost, c.url("api", repoType.apiPath(), repoID, "collaborators"), in, nil)
+}
+
+// RemoveCollaborator revokes the access of the user or team name of type
+// principalType to the repository repoID.
+func (c *Client) RemoveCollaborator(ctx context.Context, repoType RepoType, repoID string, principalType string, name string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", repoType.apiPath(), repoID, "collaborators", principalType, name), nil, nil)
+}
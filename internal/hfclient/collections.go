@@ -0,0 +1,122 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Collection describes a collection of repositories and papers.
+type Collection struct {
+	Slug        string           `json:"slug"`
+	Title       string           `json:"title"`
+	Description *string          `json:"description"`
+	Private     bool             `json:"private"`
+	Owner       CollectionOwner  `json:"owner"`
+	Items       []CollectionItem `json:"items"`
+}
+
+// CollectionOwner describes the user or organization owning a collection.
+type CollectionOwner struct {
+	Name string `json:"name"`
+}
+
+// CollectionItem describes an item of a collection.
+type CollectionItem struct {
+	// ObjectID identifies the item within its collection.
+	ObjectID string `json:"_id"`
+
+	// Type is model, dataset, space or paper.
+	Type     string          `json:"type"`
+	ID       string          `json:"id"`
+	Position int64           `json:"position"`
+	Note     *CollectionNote `json:"note"`
+}
+
+// CollectionNote is the note attached to a collection item.
+type CollectionNote struct {
+	Text string `json:"text"`
+}
+
+// CreateCollectionRequest is the body of a collection create request.
+type CreateCollectionRequest struct {
+	Title       string `json:"title"`
+	Namespace   string `json:"namespace"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+}
+
+// UpdateCollectionRequest is the body of a collection update request. Fields
+// left nil are not changed.
+type UpdateCollectionRequest struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Private     *bool   `json:"private,omitempty"`
+}
+
+// AddCollectionItemRequest is the body of a request adding an item to a
+// collection.
+type AddCollectionItemRequest struct {
+	Item struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"item"`
+	Note string `json:"note,omitempty"`
+}
+
+// UpdateCollectionItemRequest is the body of a collection item update
+// request. Fields left nil are not changed.
+type UpdateCollectionItemRequest struct {
+	Note     *string `json:"note,omitempty"`
+	Position *int64  `json:"position,omitempty"`
+}
+
+// CreateCollection creates a collection.
+func (c *Client) CreateCollection(ctx context.Context, in CreateCollectionRequest) (*Collection, error) {
+	var out Collection
+	if err := c.do(ctx, http.MethodPost, c.url("api", "collections"), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetCollection retrieves the collection slug.
+func (c *Client) GetCollection(ctx context.Context, slug string) (*Collection, error) {
+	var out Collection
+	if err := c.do(ctx, http.MethodGet, c.url("api", "collections", slug), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// UpdateCollection updates the collection slug.
+func (c *Client) UpdateCollection(ctx context.Context, slug string, in UpdateCollectionRequest) error {
+	return c.do(ctx, http.MethodPatch, c.url("api", "collections", slug), in, nil)
+}
+
+// DeleteCollection deletes the collection slug.
+func (c *Client) DeleteCollection(ctx context.Context, slug string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "collections", slug), nil, nil)
+}
+
+// AddCollectionItem adds an item to the collection slug, returning the
+// updated collection.
+func (c *Client) AddCollectionItem(ctx context.Context, slug string, in AddCollectionItemRequest) (*Collection, error) {
+	var out Collection
+	if err := c.do(ctx, http.MethodPost, c.url("api", "collections", slug, "items"), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// UpdateCollectionItem updates the item objectID of the collection slug.
+func (c *Client) UpdateCollectionItem(ctx context.Context, slug string, objectID string, in UpdateCollectionItemRequest) error {
+	return c.do(ctx, http.MethodPatch, c.url("api", "collections", slug, "items", objectID), in, nil)
+}
+
+// DeleteCollectionItem deletes the item objectID from the collection slug.
+func (c *Client) DeleteCollectionItem(ctx context.Context, slug string, objectID string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "collections", slug, "items", objectID), nil, nil)
+}
@@ -0,0 +1,145 @@
+package hfclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// CommitOperation adds, updates or deletes a single file in a commit.
+type CommitOperation struct {
+	// Path is the path of the file in the repository.
+	Path string
+
+	// Content is the new content of the file. It is ignored when Delete is
+	// set.
+	Content []byte
+
+	// Delete deletes the file instead of writing it.
+	Delete bool
+}
+
+// CreateCommitRequest describes a commit to create.
+type CreateCommitRequest struct {
+	Summary     string
+	Description string
+	Operations  []CommitOperation
+}
+
+// CommitInfo describes a commit created through the API.
+type CommitInfo struct {
+	CommitURL string `json:"commitUrl"`
+	CommitOID string `json:"commitOid"`
+}
+
+// commitLine is a line of the newline delimited JSON body of a commit.
+type commitLine struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// commitHeader is the value of the header line of a commit.
+type commitHeader struct {
+	Summary     string `json:"summary"`
+	Description string `json:"description,omitempty"`
+}
+
+// commitFile is the value of a line adding or updating a file.
+type commitFile struct {
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// commitDeletedFile is the value of a line deleting a file.
+type commitDeletedFile struct {
+	Path string `json:"path"`
+}
+
+// CreateCommit creates a single commit on the branch revision of the
+// repository repoID, applying all operations atomically.
+func (c *Client) CreateCommit(ctx context.Context, repoType RepoType, repoID string, revision string, in CreateCommitRequest) (*CommitInfo, error) {
+	lines := []commitLine{
+		{Key: "header", Value: commitHeader{Summary: in.Summary, Description: in.Description}},
+	}
+	for _, op := range in.Operations {
+		if op.Delete {
+			lines = append(lines, commitLine{Key: "deletedFile", Value: commitDeletedFile{Path: op.Path}})
+			continue
+		}
+
+		lines = append(lines, commitLine{Key: "file", Value: commitFile{
+			Path:     op.Path,
+			Content:  base64.StdEncoding.EncodeToString(op.Content),
+			Encoding: "base64",
+		}})
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("encoding request body: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url("api", repoType.apiPath(), repoID, "commit", revision), bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	var out CommitInfo
+	if err := c.doRequest(req, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DownloadFile downloads the file at path from the revision of the repository
+// repoID.
+func (c *Client) DownloadFile(ctx context.Context, repoType RepoType, repoID string, revision string, path string) ([]byte, error) {
+	return c.DownloadFileLimit(ctx, repoType, repoID, revision, path, 0)
+}
+
+// DownloadFileLimit downloads the file at path from the revision of the
+// repository repoID, failing if it is larger than limit bytes. A limit of zero
+// downloads files of any size.
+func (c *Client) DownloadFileLimit(ctx context.Context, repoType RepoType, repoID string, revision string, path string, limit int64) ([]byte, error) {
+	// Models are served from the root of the Hub, other repositories from
+	// below their type.
+	parts := []string{repoID, "resolve", revision, path}
+	if repoType != RepoTypeModel {
+		parts = append([]string{repoType.apiPath()}, parts...)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, c.url(parts...), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if limit == 0 {
+		return io.ReadAll(resp.Body)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("file %s is larger than %d bytes", path, limit)
+	}
+
+	return content, nil
+}
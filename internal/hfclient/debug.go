@@ -0,0 +1,108 @@
+package hfclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces sensitive values in logged bodies.
+const redacted = "<redacted>"
+
+// sensitiveKeys lists the JSON keys whose values are redacted from logged
+// bodies. Secrets and variables are both set as a value, and commits send
+// file contents as one, so all values are redacted.
+var sensitiveKeys = map[string]bool{
+	"value":       true,
+	"secret":      true,
+	"token":       true,
+	"accessToken": true,
+	"password":    true,
+}
+
+// traceRequest logs the body of req at TRACE level, up to limit bytes.
+func traceRequest(ctx context.Context, req *http.Request, limit int64) {
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(io.LimitReader(body, limit))
+			body.Close()
+			fields["body"] = redactBody(content)
+		}
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "API request body", fields)
+}
+
+// traceResponse logs the body of resp at TRACE level, up to limit bytes,
+// leaving the body readable by the caller. Streamed responses, such as build logs, are not
+// logged, as reading them would block until the stream ends.
+func traceResponse(ctx context.Context, resp *http.Response, limit int64) {
+	fields := map[string]interface{}{
+		"status_code":  resp.StatusCode,
+		"content_type": resp.Header.Get("Content-Type"),
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, limit))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(content), resp.Body), resp.Body}
+		fields["body"] = redactBody(content)
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "API response body", fields)
+}
+
+// redactBody renders a JSON or NDJSON body for logging, with the values of
+// sensitive keys redacted. Other bodies are only logged by their size, as
+// they cannot be redacted.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range bytes.Split(bytes.TrimSpace(body), []byte("\n")) {
+		var value interface{}
+		if err := json.Unmarshal(line, &value); err != nil {
+			return fmt.Sprintf("<%d bytes>", len(body))
+		}
+
+		redactedLine, _ := json.Marshal(redactValue(value))
+		lines = append(lines, string(redactedLine))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// redactValue redacts the values of sensitive keys from a decoded JSON value.
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if sensitiveKeys[key] {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,94 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Discussion describes a discussion or pull request of a repository.
+type Discussion struct {
+	Num           int64  `json:"num"`
+	Title         string `json:"title"`
+	IsPullRequest bool   `json:"isPullRequest"`
+	CreatedAt     string `json:"createdAt"`
+
+	// Status is open or closed, and merged or draft for pull requests.
+	Status string `json:"status"`
+
+	// Events lists the comments and changes of the discussion, starting
+	// with the comment holding its description. It is only returned by
+	// GetDiscussion.
+	Events []DiscussionEvent `json:"events"`
+}
+
+// DiscussionEvent describes a comment or change of a discussion.
+type DiscussionEvent struct {
+	ID string `json:"id"`
+
+	// Type is comment for comments, and status-change, title-change or
+	// commit for changes.
+	Type string `json:"type"`
+	Data struct {
+		Latest struct {
+			Raw string `json:"raw"`
+		} `json:"latest"`
+	} `json:"data"`
+}
+
+// CreateDiscussionRequest is the body of a discussion create request.
+type CreateDiscussionRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	PullRequest bool   `json:"pullRequest"`
+}
+
+// CreateDiscussion opens a discussion, or a pull request, on the repository
+// repoID.
+func (c *Client) CreateDiscussion(ctx context.Context, repoType RepoType, repoID string, in CreateDiscussionRequest) (*Discussion, error) {
+	var out Discussion
+	if err := c.do(ctx, http.MethodPost, c.url("api", repoType.apiPath(), repoID, "discussions"), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetDiscussion retrieves the discussion num of the repository repoID.
+func (c *Client) GetDiscussion(ctx context.Context, repoType RepoType, repoID string, num int64) (*Discussion, error) {
+	var out Discussion
+	if err := c.do(ctx, http.MethodGet, c.discussionURL(repoType, repoID, num), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// RenameDiscussion changes the title of the discussion num of the
+// repository repoID.
+func (c *Client) RenameDiscussion(ctx context.Context, repoType RepoType, repoID string, num int64, title string) error {
+	in := struct {
+		Title string `json:"title"`
+	}{
+		Title: title,
+	}
+
+	return c.do(ctx, http.MethodPost, c.discussionURL(repoType, repoID, num, "title"), in, nil)
+}
+
+// SetDiscussionStatus opens or closes the discussion num of the repository
+// repoID.
+func (c *Client) SetDiscussionStatus(ctx context.Context, repoType RepoType, repoID string, num int64, status string) error {
+	in := struct {
+		Status string `json:"status"`
+	}{
+		Status: status,
+	}
+
+	return c.do(ctx, http.MethodPost, c.discussionURL(repoType, repoID, num, "status"), in, nil)
+}
+
+// discussionURL builds the API URL of the discussion num, followed by parts.
+func (c *Client) discussionURL(repoType RepoType, repoID string, num int64, parts ...string) string {
+	return c.url(append([]string{"api", repoType.apiPath(), repoID, "discussions", strconv.FormatInt(num, 10)}, parts...)...)
+}
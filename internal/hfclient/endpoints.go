@@ -0,0 +1,109 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// InferenceEndpoint describes a dedicated Inference Endpoint.
+type InferenceEndpoint struct {
+	Name     string                    `json:"name"`
+	Type     string                    `json:"type"`
+	Provider InferenceEndpointProvider `json:"provider"`
+	Compute  InferenceEndpointCompute  `json:"compute"`
+	Model    InferenceEndpointModel    `json:"model"`
+
+	// Status is only returned by the API.
+	Status *InferenceEndpointStatus `json:"status,omitempty"`
+}
+
+// InferenceEndpointProvider describes the cloud an endpoint runs in.
+type InferenceEndpointProvider struct {
+	Vendor string `json:"vendor"`
+	Region string `json:"region"`
+}
+
+// InferenceEndpointCompute describes the instances an endpoint runs on.
+type InferenceEndpointCompute struct {
+	Accelerator  string                   `json:"accelerator"`
+	InstanceType string                   `json:"instanceType"`
+	InstanceSize string                   `json:"instanceSize"`
+	Scaling      InferenceEndpointScaling `json:"scaling"`
+}
+
+// InferenceEndpointScaling describes how an endpoint scales.
+type InferenceEndpointScaling struct {
+	MinReplica int64 `json:"minReplica"`
+	MaxReplica int64 `json:"maxReplica"`
+
+	// ScaleToZeroTimeout is the minutes of inactivity after which an
+	// endpoint with no minimum replica scales to zero.
+	ScaleToZeroTimeout *int64 `json:"scaleToZeroTimeout,omitempty"`
+}
+
+// InferenceEndpointModel describes the model an endpoint serves.
+type InferenceEndpointModel struct {
+	Repository string `json:"repository"`
+	Revision   string `json:"revision,omitempty"`
+	Framework  string `json:"framework,omitempty"`
+	Task       string `json:"task,omitempty"`
+
+	// Image selects the container image serving the model, such as
+	// {"huggingface": {}} for the default one.
+	Image map[string]interface{} `json:"image,omitempty"`
+}
+
+// InferenceEndpointStatus describes the state of an endpoint.
+type InferenceEndpointStatus struct {
+	State   string  `json:"state"`
+	URL     *string `json:"url"`
+	Message *string `json:"message"`
+}
+
+// UpdateInferenceEndpointRequest is the body of an endpoint update request.
+// Parts left nil are not changed.
+type UpdateInferenceEndpointRequest struct {
+	Type    string                    `json:"type,omitempty"`
+	Compute *InferenceEndpointCompute `json:"compute,omitempty"`
+	Model   *InferenceEndpointModel   `json:"model,omitempty"`
+}
+
+// endpointsURL builds a URL below the Inference Endpoints API.
+func (c *Client) endpointsURL(parts ...string) string {
+	return joinURL(c.config.InferenceEndpointsEndpoint, parts...)
+}
+
+// CreateInferenceEndpoint creates an endpoint owned by namespace.
+func (c *Client) CreateInferenceEndpoint(ctx context.Context, namespace string, in InferenceEndpoint) (*InferenceEndpoint, error) {
+	var out InferenceEndpoint
+	if err := c.do(ctx, http.MethodPost, c.endpointsURL("v2", "endpoint", namespace), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetInferenceEndpoint retrieves the endpoint name owned by namespace.
+func (c *Client) GetInferenceEndpoint(ctx context.Context, namespace string, name string) (*InferenceEndpoint, error) {
+	var out InferenceEndpoint
+	if err := c.do(ctx, http.MethodGet, c.endpointsURL("v2", "endpoint", namespace, name), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// UpdateInferenceEndpoint updates the endpoint name owned by namespace.
+func (c *Client) UpdateInferenceEndpoint(ctx context.Context, namespace string, name string, in UpdateInferenceEndpointRequest) (*InferenceEndpoint, error) {
+	var out InferenceEndpoint
+	if err := c.do(ctx, http.MethodPut, c.endpointsURL("v2", "endpoint", namespace, name), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DeleteInferenceEndpoint deletes the endpoint name owned by namespace.
+func (c *Client) DeleteInferenceEndpoint(ctx context.Context, namespace string, name string) error {
+	return c.do(ctx, http.MethodDelete, c.endpointsURL("v2", "endpoint", namespace, name), nil, nil)
+}
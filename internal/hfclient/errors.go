@@ -0,0 +1,74 @@
+package hfclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the API answers a request with a status code
+// other than 2xx.
+type APIError struct {
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the error message of the API, parsed from the error field
+	// of a JSON response body. It is empty if the body has none.
+	Message string
+
+	// Body is the response body, truncated to the maximum error body size of
+	// the client.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("status code: %d from %s %s: %s", e.StatusCode, e.Method, e.Path, e.Message)
+	}
+	return fmt.Sprintf("status code: %d from %s %s, response body: %s", e.StatusCode, e.Method, e.Path, e.Body)
+}
+
+// newAPIError returns the error for a response to req with statusCode and
+// body.
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	// Error messages are usually returned as {"error": "..."}, but the
+	// field is not always a string, and not every error body is JSON.
+	var errorBody struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil && len(errorBody.Error) > 0 {
+		var message string
+		if err := json.Unmarshal(errorBody.Error, &message); err == nil {
+			apiErr.Message = message
+		}
+	}
+
+	return apiErr
+}
+
+// StatusCode returns the status code of err if it is an *APIError, and 0
+// otherwise.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an *APIError for a missing resource.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
@@ -0,0 +1,94 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TreeEntry describes a file or directory in the tree of a repository.
+type TreeEntry struct {
+	// Type is either file or directory.
+	Type string `json:"type"`
+	Path string `json:"path"`
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+
+	// LFS is set for files stored in Git LFS.
+	LFS *TreeEntryLFS `json:"lfs"`
+
+	// LastCommit is the last commit touching the entry.
+	LastCommit *TreeEntryCommit `json:"lastCommit"`
+}
+
+// TreeEntryLFS describes a file stored in Git LFS.
+type TreeEntryLFS struct {
+	OID         string `json:"oid"`
+	Size        int64  `json:"size"`
+	PointerSize int64  `json:"pointerSize"`
+}
+
+// TreeEntryCommit describes the last commit touching a tree entry.
+type TreeEntryCommit struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Date  string `json:"date"`
+}
+
+// ListTreeRequest describes which entries of a repository tree to list.
+type ListTreeRequest struct {
+	// Path is the directory to list, the root of the repository if empty.
+	Path string
+
+	// Recursive lists the entries of all subdirectories as well.
+	Recursive bool
+}
+
+// ListTree lists the files and directories at the revision of the
+// repository repoID, along with the last commit touching each of them.
+func (c *Client) ListTree(ctx context.Context, repoType RepoType, repoID string, revision string, in ListTreeRequest) ([]TreeEntry, error) {
+	query := url.Values{}
+	query.Set("expand", "true")
+	if in.Recursive {
+		query.Set("recursive", "true")
+	}
+
+	// The revision may contain slashes, as refs/pr/1 does, and is escaped
+	// as a single path segment.
+	u := c.url("api", repoType.apiPath(), repoID, "tree") + "/" + url.PathEscape(revision)
+	if in.Path != "" {
+		u = joinURL(u, in.Path)
+	}
+
+	return list[TreeEntry](ctx, c, u+"?"+query.Encode(), 0)
+}
+
+// GetPathInfo retrieves the file or directory at path from the revision of
+// the repository repoID, along with the last commit touching it. It returns
+// nil if there is no such path.
+func (c *Client) GetPathInfo(ctx context.Context, repoType RepoType, repoID string, revision string, path string) (*TreeEntry, error) {
+	form := url.Values{}
+	form.Set("paths", path)
+	form.Set("expand", "true")
+
+	u := c.url("api", repoType.apiPath(), repoID, "paths-info") + "/" + url.PathEscape(revision)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var out []TreeEntry
+	if err := c.doRequest(req, &out); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range out {
+		if entry.Path == path {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,38 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// HardwareFlavor describes a hardware flavor spaces can run on.
+type HardwareFlavor struct {
+	Name        string               `json:"name"`
+	PrettyName  string               `json:"prettyName"`
+	CPU         string               `json:"cpu"`
+	RAM         string               `json:"ram"`
+	Accelerator *HardwareAccelerator `json:"accelerator"`
+
+	// UnitCostUSD is the price in USD of running on the flavor for one
+	// UnitLabel, such as one hour.
+	UnitCostUSD float64 `json:"unitCostUSD"`
+	UnitLabel   string  `json:"unitLabel"`
+}
+
+// HardwareAccelerator describes the accelerator of a hardware flavor.
+type HardwareAccelerator struct {
+	Type     string `json:"type"`
+	Model    string `json:"model"`
+	Quantity string `json:"quantity"`
+	VRAM     string `json:"vram"`
+}
+
+// ListSpaceHardware lists the hardware flavors spaces can currently run on.
+func (c *Client) ListSpaceHardware(ctx context.Context) ([]HardwareFlavor, error) {
+	var out []HardwareFlavor
+	if err := c.do(ctx, http.MethodGet, c.url("api", "spaces", "hardware"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
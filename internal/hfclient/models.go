@@ -0,0 +1,100 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Model describes a model as returned by the API.
+type Model struct {
+	ID           *string  `json:"id"`
+	Author       *string  `json:"author"`
+	Private      *bool    `json:"private"`
+	Disabled     *bool    `json:"disabled"`
+	SHA          *string  `json:"sha"`
+	LastModified *string  `json:"lastModified"`
+	Downloads    *int64   `json:"downloads"`
+	Likes        *int64   `json:"likes"`
+	PipelineTag  *string  `json:"pipeline_tag"`
+	LibraryName  *string  `json:"library_name"`
+	Tags         []string `json:"tags"`
+
+	// Gated is false when gating is disabled, and the gating mode otherwise.
+	Gated interface{} `json:"gated"`
+}
+
+// ListModelsRequest filters and sorts the models returned by ListModels.
+// Empty fields are not filtered on.
+type ListModelsRequest struct {
+	Author      string
+	Search      string
+	PipelineTag string
+	Library     string
+
+	// Sort is a field of Model to sort by, such as downloads, likes or
+	// lastModified, in descending order.
+	Sort string
+
+	// Limit bounds the number of models returned. Zero returns them all.
+	Limit int
+}
+
+// Dataset describes a dataset as returned by the API.
+type Dataset struct {
+	ID           *string `json:"id"`
+	Private      *bool   `json:"private"`
+	SHA          *string `json:"sha"`
+	LastModified *string `json:"lastModified"`
+
+	// Gated is false when gating is disabled, and the gating mode otherwise.
+	Gated interface{} `json:"gated"`
+}
+
+// GetModel retrieves the model modelID.
+func (c *Client) GetModel(ctx context.Context, modelID string) (*Model, error) {
+	var out Model
+	if err := c.do(ctx, http.MethodGet, c.url("api", "models", modelID), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ListModels lists the models matching in.
+func (c *Client) ListModels(ctx context.Context, in ListModelsRequest) ([]Model, error) {
+	query := url.Values{}
+	if in.Author != "" {
+		query.Set("author", in.Author)
+	}
+	if in.Search != "" {
+		query.Set("search", in.Search)
+	}
+	if in.PipelineTag != "" {
+		query.Set("pipeline_tag", in.PipelineTag)
+	}
+	if in.Library != "" {
+		query.Set("library", in.Library)
+	}
+	if in.Sort != "" {
+		query.Set("sort", in.Sort)
+		query.Set("direction", "-1")
+	}
+	if in.Limit > 0 {
+		query.Set("limit", strconv.Itoa(in.Limit))
+	}
+	query.Set("full", "true")
+
+	return list[Model](ctx, c, c.url("api", "models")+"?"+query.Encode(), in.Limit)
+}
+
+// GetDataset retrieves the dataset datasetID.
+func (c *Client) GetDataset(ctx context.Context, datasetID string) (*Dataset, error) {
+	var out Dataset
+	if err := c.do(ctx, http.MethodGet, c.url("api", "datasets", datasetID), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
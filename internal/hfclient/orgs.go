@@ -0,0 +1,77 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Organization describes the public overview of an organization.
+type Organization struct {
+	Name        string `json:"name"`
+	FullName    string `json:"fullname"`
+	IsVerified  bool   `json:"isVerified"`
+	NumUsers    int64  `json:"numUsers"`
+	NumModels   int64  `json:"numModels"`
+	NumDatasets int64  `json:"numDatasets"`
+	NumSpaces   int64  `json:"numSpaces"`
+
+	// Plan is the plan of the organization, such as team or enterprise. It is
+	// not returned for organizations on the free plan.
+	Plan *string `json:"plan"`
+}
+
+// GetOrganization retrieves the overview of the organization org.
+func (c *Client) GetOrganization(ctx context.Context, org string) (*Organization, error) {
+	var out Organization
+	if err := c.do(ctx, http.MethodGet, c.url("api", "organizations", org, "overview"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// OrgMember describes a member of an organization.
+type OrgMember struct {
+	User     string `json:"user"`
+	FullName string `json:"fullname"`
+
+	// Role is admin, write, contributor or read. It is only returned to
+	// admins of the organization.
+	Role string `json:"role"`
+}
+
+// ListOrgMembers lists the members of the organization org.
+func (c *Client) ListOrgMembers(ctx context.Context, org string) ([]OrgMember, error) {
+	return list[OrgMember](ctx, c, c.url("api", "organizations", org, "members"), 0)
+}
+
+// InviteOrgMember invites the user username to the organization org with
+// role. The user becomes a member once they accept the invitation.
+func (c *Client) InviteOrgMember(ctx context.Context, org string, username string, role string) error {
+	in := struct {
+		Username string `json:"username"`
+		Role     string `json:"role"`
+	}{
+		Username: username,
+		Role:     role,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "organizations", org, "members"), in, nil)
+}
+
+// SetOrgMemberRole changes the role of the member username of the
+// organization org.
+func (c *Client) SetOrgMemberRole(ctx context.Context, org string, username string, role string) error {
+	in := struct {
+		Role string `json:"role"`
+	}{
+		Role: role,
+	}
+
+	return c.do(ctx, http.MethodPut, c.url("api", "organizations", org, "members", username, "role"), in, nil)
+}
+
+// RemoveOrgMember removes the member username from the organization org.
+func (c *Client) RemoveOrgMember(ctx context.Context, org string, username string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "organizations", org, "members", username), nil, nil)
+}
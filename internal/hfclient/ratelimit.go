@@ -0,0 +1,129 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rateLimitLowWater is the share of the quota below which requests are
+// spread over the time left until the quota resets, rather than sent at
+// once and answered with a 429 later.
+const rateLimitLowWater = 0.1
+
+// resetEpochThreshold separates X-RateLimit-Reset headers sent as seconds
+// until the reset from those sent as a Unix timestamp.
+const resetEpochThreshold = 1_000_000_000
+
+// RateLimit is the quota of API requests reported by the last response that
+// carried X-RateLimit-* headers.
+type RateLimit struct {
+	// Limit is how many requests may be sent in the current window, and
+	// Remaining how many of them are left.
+	Limit     int64
+	Remaining int64
+
+	// Reset is when the quota is restored. It is zero if the API did not
+	// report it.
+	Reset time.Time
+}
+
+// RateLimit returns the quota reported by the last response carrying
+// X-RateLimit-* headers, or nil if no response carried them yet.
+func (c *Client) RateLimit() *RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+
+	if c.rateLimit == nil {
+		return nil
+	}
+
+	rateLimit := *c.rateLimit
+	return &rateLimit
+}
+
+// parseRateLimit returns the quota reported by the X-RateLimit-* headers of
+// resp, or nil if it does not carry them.
+func parseRateLimit(resp *http.Response) *RateLimit {
+	limit, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Limit"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	rateLimit := &RateLimit{Limit: limit, Remaining: remaining}
+
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset >= 0 {
+		if reset >= resetEpochThreshold {
+			rateLimit.Reset = time.Unix(reset, 0)
+		} else {
+			rateLimit.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	return rateLimit
+}
+
+// recordRateLimit keeps the quota reported by resp, if any, and logs it at
+// TRACE level so that it can be followed over the course of an apply.
+func (c *Client) recordRateLimit(ctx context.Context, resp *http.Response) {
+	rateLimit := parseRateLimit(resp)
+	if rateLimit == nil {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	c.rateLimit = rateLimit
+	c.rateLimitMu.Unlock()
+
+	fields := map[string]interface{}{
+		"limit":     rateLimit.Limit,
+		"remaining": rateLimit.Remaining,
+	}
+	if !rateLimit.Reset.IsZero() {
+		fields["reset"] = rateLimit.Reset.Format(time.RFC3339)
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "API rate limit", fields)
+}
+
+// throttle waits before a request when the remaining quota is close to
+// exhausted, spreading the requests left evenly until the quota resets.
+func (c *Client) throttle(ctx context.Context) error {
+	rateLimit := c.RateLimit()
+	if rateLimit == nil || rateLimit.Reset.IsZero() {
+		return nil
+	}
+	if float64(rateLimit.Remaining) > float64(rateLimit.Limit)*rateLimitLowWater {
+		return nil
+	}
+
+	untilReset := time.Until(rateLimit.Reset)
+	if untilReset <= 0 {
+		return nil
+	}
+
+	wait := untilReset
+	if rateLimit.Remaining > 0 {
+		wait = untilReset / time.Duration(rateLimit.Remaining+1)
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Throttling API request close to the rate limit", map[string]interface{}{
+		"remaining": rateLimit.Remaining,
+		"limit":     rateLimit.Limit,
+		"wait":      wait.String(),
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GitRef describes a branch or tag of a repository.
+type GitRef struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref"`
+	TargetCommit string `json:"targetCommit"`
+}
+
+// GitRefs lists the branches and tags of a repository.
+type GitRefs struct {
+	Branches []GitRef `json:"branches"`
+	Tags     []GitRef `json:"tags"`
+}
+
+// refURL builds the URL of the branch or tag name of the repository repoID.
+// Unlike repository IDs, ref names are escaped as a single path segment, as
+// they may contain slashes.
+func (c *Client) refURL(repoType RepoType, repoID string, kind string, name string) string {
+	return c.url("api", repoType.apiPath(), repoID, kind) + "/" + url.PathEscape(name)
+}
+
+// ListRefs lists the branches and tags of the repository repoID.
+func (c *Client) ListRefs(ctx context.Context, repoType RepoType, repoID string) (*GitRefs, error) {
+	var out GitRefs
+	if err := c.do(ctx, http.MethodGet, c.url("api", repoType.apiPath(), repoID, "refs"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// CreateBranch creates the branch name of the repository repoID, starting
+// from the revision startingPoint, or the head of the default branch if it is
+// empty.
+func (c *Client) CreateBranch(ctx context.Context, repoType RepoType, repoID string, name string, startingPoint string) error {
+	in := struct {
+		StartingPoint string `json:"startingPoint,omitempty"`
+	}{
+		StartingPoint: startingPoint,
+	}
+
+	return c.do(ctx, http.MethodPost, c.refURL(repoType, repoID, "branch", name), in, nil)
+}
+
+// DeleteBranch deletes the branch name of the repository repoID.
+func (c *Client) DeleteBranch(ctx context.Context, repoType RepoType, repoID string, name string) error {
+	return c.do(ctx, http.MethodDelete, c.refURL(repoType, repoID, "branch", name), nil, nil)
+}
+
+// CreateTag creates the tag name of the repository repoID, pointing at the
+// revision. The message is optional.
+func (c *Client) CreateTag(ctx context.Context, repoType RepoType, repoID string, name string, revision string, message string) error {
+	in := struct {
+		Tag     string `json:"tag"`
+		Message string `json:"message,omitempty"`
+	}{
+		Tag:     name,
+		Message: message,
+	}
+
+	return c.do(ctx, http.MethodPost, c.refURL(repoType, repoID, "tag", revision), in, nil)
+}
+
+// DeleteTag deletes the tag name of the repository repoID.
+func (c *Client) DeleteTag(ctx context.Context, repoType RepoType, repoID string, name string) error {
+	return c.do(ctx, http.MethodDelete, c.refURL(repoType, repoID, "tag", name), nil, nil)
+}
@@ -0,0 +1,115 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// RepoType is the type of a repository on the Hub.
+type RepoType string
+
+const (
+	RepoTypeModel   RepoType = "model"
+	RepoTypeDataset RepoType = "dataset"
+	RepoTypeSpace   RepoType = "space"
+)
+
+// apiPath returns the path segment of the API below which repositories of
+// type t live.
+func (t RepoType) apiPath() string {
+	return string(t) + "s"
+}
+
+// CreateRepoRequest is the body of a repository create request.
+type CreateRepoRequest struct {
+	Type         RepoType `json:"type"`
+	Name         string   `json:"name"`
+	Organization string   `json:"organization,omitempty"`
+	Private      *bool    `json:"private,omitempty"`
+	License      string   `json:"license,omitempty"`
+
+	// The following only apply to spaces.
+	SDK       string   `json:"sdk,omitempty"`
+	Template  string   `json:"template,omitempty"`
+	Hardware  string   `json:"hardware,omitempty"`
+	Storage   string   `json:"storage,omitempty"`
+	SleepTime *int64   `json:"sleepTime,omitempty"`
+	Region    string   `json:"region,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+
+	// IdempotencyKey lets the API deduplicate the request should it be resent
+	// after the repository was in fact created.
+	IdempotencyKey string `json:"-"`
+}
+
+// CreateRepoResponse is the response to a repository create request.
+type CreateRepoResponse struct {
+	// Name is the name of the repository, which may or may not include its
+	// namespace.
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// DeleteRepoRequest is the body of a repository delete request.
+type DeleteRepoRequest struct {
+	Type         RepoType `json:"type"`
+	Name         string   `json:"name"`
+	Organization string   `json:"organization,omitempty"`
+}
+
+// MoveRepoRequest is the body of a request to rename a repository or move it
+// to another namespace.
+type MoveRepoRequest struct {
+	FromRepo string   `json:"fromRepo"`
+	ToRepo   string   `json:"toRepo"`
+	Type     RepoType `json:"type"`
+}
+
+// RepoSettings is the body of a repository settings update. Only the fields
+// that are set are updated.
+type RepoSettings struct {
+	Private *bool `json:"private,omitempty"`
+
+	// Gated is false to disable gating, or the gating mode otherwise.
+	Gated interface{} `json:"gated,omitempty"`
+
+	// The following only apply to spaces.
+	Pinned       *bool     `json:"pinned,omitempty"`
+	CustomDomain *string   `json:"customDomain,omitempty"`
+	Tags         *[]string `json:"tags,omitempty"`
+	Models       *[]string `json:"models,omitempty"`
+	Datasets     *[]string `json:"datasets,omitempty"`
+}
+
+// CreateRepo creates a repository.
+func (c *Client) CreateRepo(ctx context.Context, in CreateRepoRequest) (*CreateRepoResponse, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.url("api", "repos", "create"), in)
+	if err != nil {
+		return nil, err
+	}
+	if in.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", in.IdempotencyKey)
+	}
+
+	var out CreateRepoResponse
+	if err := c.doRequest(req, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DeleteRepo deletes a repository.
+func (c *Client) DeleteRepo(ctx context.Context, in DeleteRepoRequest) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "repos", "delete"), in, nil)
+}
+
+// MoveRepo renames a repository or moves it to another namespace.
+func (c *Client) MoveRepo(ctx context.Context, in MoveRepoRequest) error {
+	return c.do(ctx, http.MethodPost, c.url("api", "repos", "move"), in, nil)
+}
+
+// UpdateRepoSettings updates the settings of the repository repoID.
+func (c *Client) UpdateRepoSettings(ctx context.Context, repoType RepoType, repoID string, in RepoSettings) error {
+	return c.do(ctx, http.MethodPut, c.url("api", repoType.apiPath(), repoID, "settings"), in, nil)
+}
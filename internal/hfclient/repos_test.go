@@ -0,0 +1,50 @@
+package hfclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateRepoIdempotencyKeyAcrossRetries(t *testing.T) {
+	var keys, bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		bodies = append(bodies, string(body))
+
+		// The space is created by the first attempt, but the response is
+		// lost until the last one.
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"user/demo","url":"https://huggingface.co/spaces/user/demo"}`))
+	})
+
+	created, err := client.CreateRepo(context.Background(), CreateRepoRequest{
+		Type:           RepoTypeSpace,
+		Name:           "demo",
+		SDK:            "gradio",
+		IdempotencyKey: "4a1f0c9e-create",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "user/demo" {
+		t.Errorf("created %q, expected user/demo", created.Name)
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("sent %d requests, expected 3", len(keys))
+	}
+	for i := range keys {
+		if keys[i] != "4a1f0c9e-create" {
+			t.Errorf("attempt %d sent Idempotency-Key %q, expected 4a1f0c9e-create", i+1, keys[i])
+		}
+		if bodies[i] != bodies[0] {
+			t.Errorf("attempt %d sent body %s, expected %s", i+1, bodies[i], bodies[0])
+		}
+	}
+}
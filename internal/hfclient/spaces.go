@@ -0,0 +1,393 @@
+package hfclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Space describes a space as returned by the API.
+type Space struct {
+	ID           *string       `json:"id"`
+	Author       *string       `json:"author"`
+	SDK          *string       `json:"sdk"`
+	Private      *bool         `json:"private"`
+	Pinned       *bool         `json:"pinned"`
+	Likes        *int64        `json:"likes"`
+	LastModified *string       `json:"lastModified"`
+	CustomDomain *string       `json:"customDomain"`
+	Subdomain    *string       `json:"subdomain"`
+	Host         *string       `json:"host"`
+	SHA          *string       `json:"sha"`
+	CreatedAt    *string       `json:"createdAt"`
+	Disabled     *bool         `json:"disabled"`
+	Tags         []string      `json:"tags"`
+	Models       []string      `json:"models"`
+	Datasets     []string      `json:"datasets"`
+	Runtime      *SpaceRuntime `json:"runtime"`
+
+	// OAuth is only set for spaces with hf_oauth enabled in their card.
+	OAuth *SpaceOAuth `json:"oauth"`
+
+	// Gated is false when gating is disabled, and the gating mode otherwise.
+	Gated interface{} `json:"gated"`
+}
+
+// String renders the space with its pointer fields dereferenced so that it
+// can be logged in a readable form.
+func (s Space) String() string {
+	return formatDereferenced(reflect.ValueOf(s))
+}
+
+// SpaceOAuth describes the OAuth app of a space.
+type SpaceOAuth struct {
+	ClientID string   `json:"clientId"`
+	Scopes   []string `json:"scopes"`
+}
+
+// SpaceRuntime describes the runtime of a space as reported by the API.
+type SpaceRuntime struct {
+	Stage    string        `json:"stage"`
+	Hardware SpaceHardware `json:"hardware"`
+	Storage  SpaceStorage  `json:"storage"`
+
+	// SleepTime is nil for spaces that never go to sleep.
+	SleepTime *int64 `json:"gcTimeout"`
+
+	// ErrorMessage explains why a space failed to build or start.
+	ErrorMessage *string `json:"errorMessage"`
+
+	// DevMode reports whether the space can be connected to over SSH.
+	DevMode *bool `json:"devMode"`
+}
+
+// String renders the runtime with its pointer fields dereferenced.
+func (r SpaceRuntime) String() string {
+	return formatDereferenced(reflect.ValueOf(r))
+}
+
+// SpaceHardware describes the hardware a space is currently running on and
+// the hardware it has requested.
+type SpaceHardware struct {
+	Current   *string `json:"current"`
+	Requested *string `json:"requested"`
+}
+
+// SpaceStorage describes the persistent storage tier a space currently has
+// and the tier it has requested.
+type SpaceStorage struct {
+	Current   *string `json:"current"`
+	Requested *string `json:"requested"`
+}
+
+// SpaceSecret describes a secret of a space. Secret values are never
+// returned by the API.
+type SpaceSecret struct {
+	Description string `json:"description,omitempty"`
+	UpdatedAt   string `json:"updatedAt,omitempty"`
+}
+
+// SpaceVariable describes a variable of a space.
+type SpaceVariable struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	UpdatedAt   string `json:"updatedAt,omitempty"`
+}
+
+// spaceKeyValue is the body of a request to add or update a secret or
+// variable.
+type spaceKeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// DuplicateSpaceRequest is the body of a request to duplicate a space. Empty
+// fields are copied from the duplicated space.
+type DuplicateSpaceRequest struct {
+	// Repository is the ID of the new space, in the form namespace/name.
+	Repository string `json:"repository"`
+
+	Private   *bool  `json:"private,omitempty"`
+	Hardware  string `json:"hardware,omitempty"`
+	Storage   string `json:"storage,omitempty"`
+	SleepTime *int64 `json:"sleepTimeSeconds,omitempty"`
+
+	// Secrets are not copied, only variables are. Both are set before the
+	// new space is first built.
+	Secrets   []spaceKeyValue `json:"secrets,omitempty"`
+	Variables []spaceKeyValue `json:"variables,omitempty"`
+}
+
+// AddSecret adds a secret to the new space.
+func (r *DuplicateSpaceRequest) AddSecret(key string, value string) {
+	r.Secrets = append(r.Secrets, spaceKeyValue{Key: key, Value: value})
+}
+
+// AddVariable adds a variable to the new space, overriding the variable of
+// the duplicated space with the same key.
+func (r *DuplicateSpaceRequest) AddVariable(key string, value string) {
+	r.Variables = append(r.Variables, spaceKeyValue{Key: key, Value: value})
+}
+
+// spaceKey is the body of a request to delete a secret or variable.
+type spaceKey struct {
+	Key string `json:"key"`
+}
+
+// ListSpacesRequest filters and sorts the spaces returned by ListSpaces.
+// Empty fields are not filtered on.
+type ListSpacesRequest struct {
+	Author string
+	Search string
+	SDK    string
+
+	// Sort is a field of Space to sort by, such as likes or lastModified,
+	// in descending order.
+	Sort string
+
+	// Limit bounds the number of spaces returned. Zero returns them all.
+	Limit int
+}
+
+// ListSpaces lists the spaces matching in.
+func (c *Client) ListSpaces(ctx context.Context, in ListSpacesRequest) ([]Space, error) {
+	query := url.Values{}
+	if in.Author != "" {
+		query.Set("author", in.Author)
+	}
+	if in.Search != "" {
+		query.Set("search", in.Search)
+	}
+	if in.SDK != "" {
+		query.Set("filter", in.SDK)
+	}
+	if in.Sort != "" {
+		query.Set("sort", in.Sort)
+		query.Set("direction", "-1")
+	}
+	if in.Limit > 0 {
+		query.Set("limit", strconv.Itoa(in.Limit))
+	}
+	query.Set("full", "true")
+
+	return list[Space](ctx, c, c.url("api", "spaces")+"?"+query.Encode(), in.Limit)
+}
+
+// DuplicateSpace copies the files and settings of the space fromID into a new
+// space.
+func (c *Client) DuplicateSpace(ctx context.Context, fromID string, in DuplicateSpaceRequest) error {
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", fromID, "duplicate"), in, nil)
+}
+
+// GetSpace retrieves the space spaceID.
+func (c *Client) GetSpace(ctx context.Context, spaceID string) (*Space, error) {
+	var out Space
+	if err := c.do(ctx, http.MethodGet, c.url("api", "spaces", spaceID), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetSpaceRuntime retrieves the runtime of the space spaceID.
+func (c *Client) GetSpaceRuntime(ctx context.Context, spaceID string) (*SpaceRuntime, error) {
+	var out SpaceRuntime
+	if err := c.do(ctx, http.MethodGet, c.url("api", "spaces", spaceID, "runtime"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetSpaceBuildLogs retrieves at most limit bytes of the build logs of the
+// space spaceID.
+func (c *Client) GetSpaceBuildLogs(ctx context.Context, spaceID string, limit int64) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.url("api", "spaces", spaceID, "logs", "build"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The logs are streamed back in chunks, so read until the server closes
+	// the stream or the limit is reached, whichever comes first.
+	return io.ReadAll(io.LimitReader(resp.Body, limit))
+}
+
+// ImportSpaceFromGit imports the contents of the Git repository at gitURL
+// into the space spaceID.
+func (c *Client) ImportSpaceFromGit(ctx context.Context, spaceID string, gitURL string) error {
+	in := struct {
+		URL string `json:"url"`
+	}{
+		URL: gitURL,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "import"), in, nil)
+}
+
+// SetSpaceHardware requests the hardware flavor for the space spaceID. The
+// region is left to the API when empty.
+func (c *Client) SetSpaceHardware(ctx context.Context, spaceID string, flavor string, region string) error {
+	in := struct {
+		Flavor string `json:"flavor"`
+		Region string `json:"region,omitempty"`
+	}{
+		Flavor: flavor,
+		Region: region,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "hardware"), in, nil)
+}
+
+// SetSpaceStorage requests the persistent storage tier for the space spaceID.
+func (c *Client) SetSpaceStorage(ctx context.Context, spaceID string, tier string) error {
+	in := struct {
+		Tier string `json:"tier"`
+	}{
+		Tier: tier,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "storage"), in, nil)
+}
+
+// DeleteSpaceStorage deletes the persistent storage of the space spaceID,
+// along with all the data stored on it.
+func (c *Client) DeleteSpaceStorage(ctx context.Context, spaceID string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "spaces", spaceID, "storage"), nil, nil)
+}
+
+// SetSpaceSleepTime sets the seconds of inactivity after which the space
+// spaceID goes to sleep.
+func (c *Client) SetSpaceSleepTime(ctx context.Context, spaceID string, seconds int64) error {
+	in := struct {
+		Seconds int64 `json:"seconds"`
+	}{
+		Seconds: seconds,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "sleeptime"), in, nil)
+}
+
+// PauseSpace pauses the space spaceID until it is restarted. Paused spaces do
+// not run on, nor are billed for, their hardware.
+func (c *Client) PauseSpace(ctx context.Context, spaceID string) error {
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "pause"), nil, nil)
+}
+
+// RestartSpace restarts the space spaceID, which also resumes a paused
+// space. A factory reboot rebuilds the image of the space from scratch
+// rather than reusing the cached one.
+func (c *Client) RestartSpace(ctx context.Context, spaceID string, factoryReboot bool) error {
+	url := c.url("api", "spaces", spaceID, "restart")
+	if factoryReboot {
+		url += "?factory=true"
+	}
+
+	return c.do(ctx, http.MethodPost, url, nil, nil)
+}
+
+// SetSpaceDevMode enables or disables Dev Mode on the space spaceID, which
+// lets its owner connect to the running space over SSH.
+func (c *Client) SetSpaceDevMode(ctx context.Context, spaceID string, enabled bool) error {
+	in := struct {
+		Enabled bool `json:"enabled"`
+	}{
+		Enabled: enabled,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "dev-mode"), in, nil)
+}
+
+// ListSpaceSecrets lists the secrets of the space spaceID by key.
+func (c *Client) ListSpaceSecrets(ctx context.Context, spaceID string) (map[string]SpaceSecret, error) {
+	var out map[string]SpaceSecret
+	if err := c.do(ctx, http.MethodGet, c.url("api", "spaces", spaceID, "secrets"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SetSpaceSecret adds or updates a secret of the space spaceID. The
+// description is optional.
+func (c *Client) SetSpaceSecret(ctx context.Context, spaceID string, key string, value string, description string) error {
+	in := spaceKeyValue{
+		Key:         key,
+		Value:       value,
+		Description: description,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "secrets"), in, nil)
+}
+
+// DeleteSpaceSecret deletes a secret of the space spaceID.
+func (c *Client) DeleteSpaceSecret(ctx context.Context, spaceID string, key string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "spaces", spaceID, "secrets"), spaceKey{Key: key}, nil)
+}
+
+// ListSpaceVariables lists the variables of the space spaceID by key.
+func (c *Client) ListSpaceVariables(ctx context.Context, spaceID string) (map[string]SpaceVariable, error) {
+	var out map[string]SpaceVariable
+	if err := c.do(ctx, http.MethodGet, c.url("api", "spaces", spaceID, "variables"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SetSpaceVariable adds or updates a variable of the space spaceID. The
+// description is optional.
+func (c *Client) SetSpaceVariable(ctx context.Context, spaceID string, key string, value string, description string) error {
+	in := spaceKeyValue{
+		Key:         key,
+		Value:       value,
+		Description: description,
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "spaces", spaceID, "variables"), in, nil)
+}
+
+// DeleteSpaceVariable deletes a variable of the space spaceID.
+func (c *Client) DeleteSpaceVariable(ctx context.Context, spaceID string, key string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "spaces", spaceID, "variables"), spaceKey{Key: key}, nil)
+}
+
+// formatDereferenced renders value the way %+v does, but with pointers
+// dereferenced and nil ones rendered as <nil>, so that every field of a
+// struct is logged without listing them by hand.
+func formatDereferenced(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return "<nil>"
+		}
+		return formatDereferenced(value.Elem())
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Type().Field(i); field.IsExported() {
+				fields = append(fields, field.Name+":"+formatDereferenced(value.Field(i)))
+			}
+		}
+		return "{" + strings.Join(fields, " ") + "}"
+	case reflect.Slice:
+		elements := make([]string, value.Len())
+		for i := range elements {
+			elements[i] = formatDereferenced(value.Index(i))
+		}
+		return "[" + strings.Join(elements, " ") + "]"
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
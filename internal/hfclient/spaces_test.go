@@ -0,0 +1,50 @@
+package hfclient
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSpaceString(t *testing.T) {
+	id, author, sdk, hardware := "acme/demo", "acme", "gradio", "t4-small"
+	errorMessage := "Build failed"
+	private, disabled, devMode := true, false, true
+	likes, sleepTime := int64(42), int64(3600)
+
+	space := Space{
+		ID:       &id,
+		Author:   &author,
+		SDK:      &sdk,
+		Private:  &private,
+		Likes:    &likes,
+		Disabled: &disabled,
+		Tags:     []string{"demo"},
+		Models:   []string{"acme/classifier"},
+		Runtime: &SpaceRuntime{
+			Stage:        "RUNTIME_ERROR",
+			Hardware:     SpaceHardware{Current: &hardware, Requested: &hardware},
+			SleepTime:    &sleepTime,
+			ErrorMessage: &errorMessage,
+			DevMode:      &devMode,
+		},
+		OAuth: &SpaceOAuth{ClientID: "client", Scopes: []string{"openid"}},
+		Gated: "auto",
+	}
+
+	// Spaces are logged with %+v, as in the debug logs of the provider.
+	rendered := fmt.Sprintf("%+v", space)
+
+	expected := "{ID:acme/demo Author:acme SDK:gradio Private:true Pinned:<nil> Likes:42 LastModified:<nil> " +
+		"CustomDomain:<nil> Subdomain:<nil> Host:<nil> SHA:<nil> CreatedAt:<nil> Disabled:false " +
+		"Tags:[demo] Models:[acme/classifier] Datasets:[] " +
+		"Runtime:{Stage:RUNTIME_ERROR Hardware:{Current:t4-small Requested:t4-small} Storage:{Current:<nil> Requested:<nil>} " +
+		"SleepTime:3600 ErrorMessage:Build failed DevMode:true} " +
+		"OAuth:{ClientID:client Scopes:[openid]} Gated:auto}"
+	if rendered != expected {
+		t.Errorf("space rendered as %s, expected %s", rendered, expected)
+	}
+	if strings.Contains(rendered, "0x") {
+		t.Errorf("space rendered with pointers: %s", rendered)
+	}
+}
@@ -0,0 +1,51 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// AccessToken describes a user access token.
+type AccessToken struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName"`
+
+	// Role is read or write for classic tokens, and fineGrained otherwise.
+	Role        string            `json:"role"`
+	FineGrained *FineGrainedScope `json:"fineGrained,omitempty"`
+
+	// ExpiresAt is when the token expires, in RFC 3339 format. Tokens
+	// without it never expire.
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+	CreatedAt string  `json:"createdAt,omitempty"`
+
+	// Token is the value of the token. It is only returned when the token
+	// is created.
+	Token string `json:"token,omitempty"`
+}
+
+// CreateAccessToken creates an access token for the authenticated user. The
+// value of the token is only ever returned here.
+func (c *Client) CreateAccessToken(ctx context.Context, in AccessToken) (*AccessToken, error) {
+	var out AccessToken
+	if err := c.do(ctx, http.MethodPost, c.url("api", "settings", "tokens"), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetAccessToken retrieves the access token id, without its value.
+func (c *Client) GetAccessToken(ctx context.Context, id string) (*AccessToken, error) {
+	var out AccessToken
+	if err := c.do(ctx, http.MethodGet, c.url("api", "settings", "tokens", id), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DeleteAccessToken revokes and deletes the access token id.
+func (c *Client) DeleteAccessToken(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "settings", "tokens", id), nil, nil)
+}
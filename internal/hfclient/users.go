@@ -0,0 +1,94 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// WhoAmI describes the user or organization a token belongs to.
+type WhoAmI struct {
+	Type     string      `json:"type"`
+	Name     string      `json:"name"`
+	FullName string      `json:"fullname"`
+	IsPro    bool        `json:"isPro"`
+	Plan     *string     `json:"plan"`
+	Orgs     []WhoAmIOrg `json:"orgs"`
+	Auth     WhoAmIAuth  `json:"auth"`
+}
+
+// WhoAmIOrg describes an organization the authenticated user is a member of.
+type WhoAmIOrg struct {
+	Name      string `json:"name"`
+	FullName  string `json:"fullname"`
+	RoleInOrg string `json:"roleInOrg"`
+}
+
+// WhoAmIAuth describes how a request was authenticated.
+type WhoAmIAuth struct {
+	Type        string             `json:"type"`
+	AccessToken *WhoAmIAccessToken `json:"accessToken"`
+}
+
+// WhoAmIAccessToken describes the access token a request was authenticated
+// with.
+type WhoAmIAccessToken struct {
+	DisplayName string `json:"displayName"`
+
+	// Role is read or write for classic tokens, and fineGrained otherwise.
+	Role        string            `json:"role"`
+	FineGrained *FineGrainedScope `json:"fineGrained"`
+}
+
+// FineGrainedScope lists the permissions of a fine-grained token.
+type FineGrainedScope struct {
+	Global []string            `json:"global"`
+	Scoped []FineGrainedEntity `json:"scoped"`
+}
+
+// FineGrainedEntity lists the permissions of a fine-grained token on a single
+// user, organization or repository.
+type FineGrainedEntity struct {
+	Entity struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"entity"`
+	Permissions []string `json:"permissions"`
+}
+
+// WhoAmI retrieves the user or organization the client is authenticated as.
+func (c *Client) WhoAmI(ctx context.Context) (*WhoAmI, error) {
+	var out WhoAmI
+	if err := c.do(ctx, http.MethodGet, c.url("api", "whoami-v2"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// User describes the public overview of a user.
+type User struct {
+	User         string    `json:"user"`
+	FullName     string    `json:"fullname"`
+	IsPro        bool      `json:"isPro"`
+	Orgs         []UserOrg `json:"orgs"`
+	NumModels    int64     `json:"numModels"`
+	NumDatasets  int64     `json:"numDatasets"`
+	NumSpaces    int64     `json:"numSpaces"`
+	NumFollowers int64     `json:"numFollowers"`
+}
+
+// UserOrg describes an organization a user is a public member of.
+type UserOrg struct {
+	Name     string `json:"name"`
+	FullName string `json:"fullname"`
+}
+
+// GetUser retrieves the overview of the user username.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	var out User
+	if err := c.do(ctx, http.MethodGet, c.url("api", "users", username, "overview"), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
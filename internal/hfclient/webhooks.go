@@ -0,0 +1,75 @@
+package hfclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Webhook describes a webhook subscribed to events of repositories, users or
+// organizations.
+type Webhook struct {
+	ID       string           `json:"id,omitempty"`
+	URL      string           `json:"url"`
+	Watched  []WebhookWatched `json:"watched"`
+	Domains  []string         `json:"domains"`
+	Secret   *string          `json:"secret,omitempty"`
+	Disabled bool             `json:"disabled,omitempty"`
+}
+
+// WebhookWatched is an entity a webhook is subscribed to.
+type WebhookWatched struct {
+	// Type is model, dataset, space, user or org.
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// webhookResponse wraps a webhook returned by the API.
+type webhookResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+// CreateWebhook creates a webhook.
+func (c *Client) CreateWebhook(ctx context.Context, in Webhook) (*Webhook, error) {
+	var out webhookResponse
+	if err := c.do(ctx, http.MethodPost, c.url("api", "settings", "webhooks"), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Webhook, nil
+}
+
+// GetWebhook retrieves the webhook id.
+func (c *Client) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	var out webhookResponse
+	if err := c.do(ctx, http.MethodGet, c.url("api", "settings", "webhooks", id), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Webhook, nil
+}
+
+// UpdateWebhook replaces the URL, watched entities, domains and secret of the
+// webhook id.
+func (c *Client) UpdateWebhook(ctx context.Context, id string, in Webhook) (*Webhook, error) {
+	var out webhookResponse
+	if err := c.do(ctx, http.MethodPost, c.url("api", "settings", "webhooks", id), in, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Webhook, nil
+}
+
+// SetWebhookDisabled disables or enables the webhook id.
+func (c *Client) SetWebhookDisabled(ctx context.Context, id string, disabled bool) error {
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+
+	return c.do(ctx, http.MethodPost, c.url("api", "settings", "webhooks", id, action), nil, nil)
+}
+
+// DeleteWebhook deletes the webhook id.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, c.url("api", "settings", "webhooks", id), nil, nil)
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+const (
+	// defaultEphemeralTokenName is the display name of ephemeral tokens
+	// unless configured otherwise.
+	defaultEphemeralTokenName = "terraform-ephemeral"
+
+	// defaultEphemeralTokenTTL is how long ephemeral tokens are valid for
+	// unless configured otherwise.
+	defaultEphemeralTokenTTL = time.Hour
+
+	// ephemeralTokenIDPrivateKey is the private data key the ID of an
+	// ephemeral token is kept under until it is closed.
+	ephemeralTokenIDPrivateKey = "token_id"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &AccessTokenEphemeralResource{}
+)
+
+// AccessTokenEphemeralResource defines the ephemeral resource implementation.
+type AccessTokenEphemeralResource struct {
+	client *hfclient.Client
+}
+
+// AccessTokenEphemeralResourceModel describes the ephemeral resource data
+// model.
+type AccessTokenEphemeralResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Role              types.String `tfsdk:"role"`
+	GlobalPermissions types.Set    `tfsdk:"global_permissions"`
+	ScopedPermissions types.Set    `tfsdk:"scoped_permissions"`
+	TTL               types.String `tfsdk:"ttl"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+	Value             types.String `tfsdk:"value"`
+}
+
+func (e *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (e *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a short-lived access token of the authenticated user for the duration of a Terraform run. The token is never written to state or plan, and is deleted once Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the token.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The display name of the token. Defaults to `%s`.", defaultEphemeralTokenName),
+				Optional:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the token, `read` or `write` for classic tokens, or `fineGrained` for tokens limited to the configured permissions. Defaults to `fineGrained`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(accessTokenRoles...),
+				},
+			},
+			"global_permissions": schema.SetAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token that are not limited to a user, organization or repository, such as `inference.serverless.write`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"scoped_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token on a single user, organization or repository.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the entity, one of `model`, `dataset`, `space`, `user` or `org`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(webhookWatchedTypes...),
+							},
+						},
+						"entity_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the user or organization, or the ID of the repository.",
+							Required:            true,
+						},
+						"permissions": schema.SetAttribute{
+							MarkdownDescription: "The permissions on the entity, such as `repo.content.read` or `repo.write`.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the token is valid for, such as `30m`. The token expires on its own should Terraform fail to delete it. Defaults to `1h`.",
+				Optional:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the token expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = data.client
+}
+
+func (e *AccessTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAccessToken(data.Role, data.GlobalPermissions, data.ScopedPermissions, types.StringNull(), &resp.Diagnostics)
+
+	ttl := parseDuration(data.TTL, defaultEphemeralTokenTTL, path.Root("ttl"), &resp.Diagnostics)
+	if ttl <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid Duration", "ttl must be positive.")
+	}
+}
+
+func (e *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.IsNull() {
+		data.Name = types.StringValue(defaultEphemeralTokenName)
+	}
+
+	ttl := parseDuration(data.TTL, defaultEphemeralTokenTTL, path.Root("ttl"), &resp.Diagnostics)
+	data.ExpiresAt = types.StringValue(time.Now().Add(ttl).UTC().Format(time.RFC3339))
+
+	in := accessTokenRequest(ctx, data.Name, data.Role, data.GlobalPermissions, data.ScopedPermissions, data.ExpiresAt, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := e.client.CreateAccessToken(ctx, in)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create ephemeral access token", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created ephemeral access token %s expiring at %s", token.ID, data.ExpiresAt.ValueString()))
+
+	data.ID = types.StringValue(token.ID)
+	data.Value = types.StringValue(token.Token)
+
+	// Keep the ID of the token to delete it on close.
+	id, _ := json.Marshal(token.ID)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralTokenIDPrivateKey, id)...)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *AccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, ephemeralTokenIDPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(value, &id); err != nil {
+		resp.Diagnostics.AddError("Invalid Private Data", fmt.Sprintf("Unable to decode the ID of the ephemeral access token, got error: %s", err))
+		return
+	}
+
+	// A token that already expired may be gone.
+	err := e.client.DeleteAccessToken(ctx, id)
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete ephemeral access token", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Deleted ephemeral access token %s", id))
+}
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &AccessTokenResource{}
+	_ resource.ResourceWithConfigure      = &AccessTokenResource{}
+	_ resource.ResourceWithValidateConfig = &AccessTokenResource{}
+)
+
+// fineGrainedRole is the role of tokens limited to a set of permissions.
+const fineGrainedRole = "fineGrained"
+
+// accessTokenRoles lists the roles of access tokens.
+var accessTokenRoles = []string{"read", "write", fineGrainedRole}
+
+// AccessTokenResource defines the resource implementation.
+type AccessTokenResource struct {
+	client *hfclient.Client
+}
+
+// AccessTokenResourceModel describes the resource data model.
+type AccessTokenResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Role              types.String `tfsdk:"role"`
+	GlobalPermissions types.Set    `tfsdk:"global_permissions"`
+	ScopedPermissions types.Set    `tfsdk:"scoped_permissions"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+	Value             types.String `tfsdk:"value"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+}
+
+// AccessTokenScopeModel describes the permissions of a fine-grained token on
+// a single user, organization or repository.
+type AccessTokenScopeModel struct {
+	EntityType  types.String `tfsdk:"entity_type"`
+	EntityName  types.String `tfsdk:"entity_name"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+func (r *AccessTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an access token of the authenticated user. The value of the token is only known when it is created, and stored in state as a sensitive attribute. Every change creates a new token.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the token.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the token, `read` or `write` for classic tokens, or `fineGrained` for tokens limited to the configured permissions. Defaults to `fineGrained`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(fineGrainedRole),
+				Validators: []validator.String{
+					stringvalidator.OneOf(accessTokenRoles...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"global_permissions": schema.SetAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token that are not limited to a user, organization or repository, such as `inference.serverless.write`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"scoped_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token on a single user, organization or repository.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the entity, one of `model`, `dataset`, `space`, `user` or `org`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(webhookWatchedTypes...),
+							},
+						},
+						"entity_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the user or organization, or the ID of the repository.",
+							Required:            true,
+						},
+						"permissions": schema.SetAttribute{
+							MarkdownDescription: "The permissions on the entity, such as `repo.content.read` or `repo.write`.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the token expires, in RFC 3339 format. Tokens without it never expire.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the token.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the token was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AccessTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *AccessTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAccessToken(data.Role, data.GlobalPermissions, data.ScopedPermissions, data.ExpiresAt, &resp.Diagnostics)
+}
+
+func (r *AccessTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := accessTokenRequest(ctx, data.Name, data.Role, data.GlobalPermissions, data.ScopedPermissions, data.ExpiresAt, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.CreateAccessToken(ctx, in)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create access token", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created access token %s", token.ID))
+
+	data.ID = types.StringValue(token.ID)
+	data.Value = types.StringValue(token.Token)
+	data.CreatedAt = types.StringValue(token.CreatedAt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The value of a token cannot be read back, and revoked tokens are
+	// deleted, so all there is to check is whether the token still exists.
+	token, err := r.client.GetAccessToken(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Access token %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read access token", err)
+		return
+	}
+
+	data.Name = types.StringValue(token.DisplayName)
+	if token.CreatedAt != "" {
+		data.CreatedAt = types.StringValue(token.CreatedAt)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *AccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every configurable attribute forces a new token to be created, so
+	// there is nothing to update.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAccessToken(ctx, data.ID.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete access token", err)
+		return
+	}
+}
+
+// validateAccessToken checks that permissions are only configured for
+// fine-grained tokens, and that expiresAt is a valid timestamp.
+func validateAccessToken(role types.String, global types.Set, scoped types.Set, expiresAt types.String, diags *diag.Diagnostics) {
+	if !role.IsNull() && !role.IsUnknown() && role.ValueString() != fineGrainedRole {
+		if !global.IsNull() || !scoped.IsNull() {
+			diags.AddAttributeError(
+				path.Root("role"),
+				"Permissions Require A Fine-Grained Token",
+				fmt.Sprintf("global_permissions and scoped_permissions can only be set for tokens with the %s role, not %s.", fineGrainedRole, role.ValueString()),
+			)
+		}
+	}
+
+	if !expiresAt.IsNull() && !expiresAt.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, expiresAt.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("expires_at"),
+				"Invalid Expiry",
+				fmt.Sprintf("expires_at must be a timestamp in RFC 3339 format, such as 2030-01-01T00:00:00Z: %s", err),
+			)
+		}
+	}
+}
+
+// accessTokenRequest builds the body of an access token create request.
+func accessTokenRequest(ctx context.Context, name types.String, role types.String, global types.Set, scoped types.Set, expiresAt types.String, diags *diag.Diagnostics) hfclient.AccessToken {
+	in := hfclient.AccessToken{
+		DisplayName: name.ValueString(),
+		Role:        role.ValueString(),
+		ExpiresAt:   expiresAt.ValueStringPointer(),
+	}
+	if in.Role == "" {
+		in.Role = fineGrainedRole
+	}
+
+	if in.Role != fineGrainedRole {
+		return in
+	}
+
+	in.FineGrained = &hfclient.FineGrainedScope{
+		Global: []string{},
+		Scoped: []hfclient.FineGrainedEntity{},
+	}
+	if !global.IsNull() {
+		diags.Append(global.ElementsAs(ctx, &in.FineGrained.Global, false)...)
+	}
+
+	var scopes []AccessTokenScopeModel
+	if !scoped.IsNull() {
+		diags.Append(scoped.ElementsAs(ctx, &scopes, false)...)
+	}
+	for _, scope := range scopes {
+		var entity hfclient.FineGrainedEntity
+		entity.Entity.Type = scope.EntityType.ValueString()
+		entity.Entity.Name = scope.EntityName.ValueString()
+		diags.Append(scope.Permissions.ElementsAs(ctx, &entity.Permissions, false)...)
+
+		in.FineGrained.Scoped = append(in.FineGrained.Scoped, entity)
+	}
+
+	return in
+}
+
+func NewAccessTokenResource() resource.Resource {
+	return &AccessTokenResource{}
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// readmePath is the path of the card of a repository, whose YAML frontmatter
+// holds its metadata.
+const readmePath = "README.md"
+
+// frontmatterDelimiter opens and closes the YAML frontmatter of a card.
+const frontmatterDelimiter = "---\n"
+
+// cardColors lists the colors the thumbnail gradient of a space can use.
+var cardColors = []string{"red", "yellow", "green", "blue", "indigo", "purple", "pink", "gray"}
+
+// escapedRuneRegexp matches the escapes YAML emits for characters outside of
+// the Basic Multilingual Plane, such as most emoji.
+var escapedRuneRegexp = regexp.MustCompile(`\\U[0-9A-F]{8}`)
+
+// SpaceCardMetadataModel describes the card_metadata attribute.
+type SpaceCardMetadataModel struct {
+	Title         types.String `tfsdk:"title"`
+	Emoji         types.String `tfsdk:"emoji"`
+	ColorFrom     types.String `tfsdk:"color_from"`
+	ColorTo       types.String `tfsdk:"color_to"`
+	Pinned        types.Bool   `tfsdk:"pinned"`
+	License       types.String `tfsdk:"license"`
+	AppFile       types.String `tfsdk:"app_file"`
+	PythonVersion types.String `tfsdk:"python_version"`
+}
+
+// cardMetadataAttrTypes are the attribute types of the card_metadata object.
+var cardMetadataAttrTypes = map[string]attr.Type{
+	"title":          types.StringType,
+	"emoji":          types.StringType,
+	"color_from":     types.StringType,
+	"color_to":       types.StringType,
+	"pinned":         types.BoolType,
+	"license":        types.StringType,
+	"app_file":       types.StringType,
+	"python_version": types.StringType,
+}
+
+// stringFields maps the frontmatter keys of the string attributes of m to
+// their values.
+func (m *SpaceCardMetadataModel) stringFields() map[string]*types.String {
+	return map[string]*types.String{
+		"title":          &m.Title,
+		"emoji":          &m.Emoji,
+		"colorFrom":      &m.ColorFrom,
+		"colorTo":        &m.ColorTo,
+		"license":        &m.License,
+		"app_file":       &m.AppFile,
+		"python_version": &m.PythonVersion,
+	}
+}
+
+// cardMetadata returns the card_metadata attribute, or nil if it is not set.
+func cardMetadata(ctx context.Context, object types.Object, diags *diag.Diagnostics) *SpaceCardMetadataModel {
+	if object.IsNull() || object.IsUnknown() {
+		return nil
+	}
+
+	var metadata SpaceCardMetadataModel
+	diags.Append(object.As(ctx, &metadata, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &metadata
+}
+
+// repoCard is the card of a repository, split into its frontmatter and body.
+type repoCard struct {
+	readme      []byte
+	frontmatter *yaml.Node
+	body        []byte
+}
+
+// loadRepoCard downloads the card of the repository repoID, treating a
+// missing card as an empty one.
+func loadRepoCard(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string) (*repoCard, error) {
+	readme, err := client.DownloadFile(ctx, repoType, repoID, defaultBranch, readmePath)
+	if err != nil && !hfclient.IsNotFound(err) {
+		return nil, err
+	}
+
+	frontmatter, body, err := parseReadme(readme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repoCard{readme: readme, frontmatter: frontmatter, body: body}, nil
+}
+
+// get returns the value of key in the frontmatter, or nil if it is missing.
+func (c *repoCard) get(key string) *yaml.Node {
+	return mappingValue(c.frontmatter, key)
+}
+
+// set sets key in the frontmatter to value.
+func (c *repoCard) set(key string, value interface{}) {
+	setMappingValue(c.frontmatter, key, value)
+}
+
+// save commits the card to the repository repoID, keeping every key of the
+// frontmatter that was not set and the body. Nothing is committed if the
+// card is unchanged.
+func (c *repoCard) save(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string) error {
+	if len(c.frontmatter.Content) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.frontmatter); err != nil {
+		return fmt.Errorf("encoding card metadata: %w", err)
+	}
+	rendered := buf.Bytes()
+
+	// The encoder escapes emoji, which are kept literal in cards so that they
+	// stay readable.
+	rendered = escapedRuneRegexp.ReplaceAllFunc(rendered, func(escape []byte) []byte {
+		code, _ := strconv.ParseUint(string(escape[2:]), 16, 32)
+		return []byte(string(rune(code)))
+	})
+
+	updated := append([]byte(frontmatterDelimiter), rendered...)
+	updated = append(updated, frontmatterDelimiter...)
+	updated = append(updated, c.body...)
+
+	if bytes.Equal(updated, c.readme) {
+		return nil
+	}
+
+	_, err := client.CreateCommit(ctx, repoType, repoID, defaultBranch, hfclient.CreateCommitRequest{
+		Summary: "Update card metadata",
+		Operations: []hfclient.CommitOperation{
+			{Path: readmePath, Content: updated},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.readme = updated
+	return nil
+}
+
+// readCardMetadata refreshes the attributes of metadata that are set from
+// card. Attributes that are not set are not managed and left alone.
+func readCardMetadata(card *repoCard, metadata *SpaceCardMetadataModel) {
+	for key, value := range metadata.stringFields() {
+		if value.IsNull() {
+			continue
+		}
+
+		*value = types.StringNull()
+		if node := card.get(key); node != nil {
+			*value = types.StringValue(node.Value)
+		}
+	}
+
+	if !metadata.Pinned.IsNull() {
+		metadata.Pinned = types.BoolNull()
+		if node := card.get("pinned"); node != nil {
+			var pinned bool
+			if err := node.Decode(&pinned); err == nil {
+				metadata.Pinned = types.BoolValue(pinned)
+			}
+		}
+	}
+}
+
+// writeCardMetadata sets the attributes of metadata that are set in card.
+func writeCardMetadata(card *repoCard, metadata *SpaceCardMetadataModel) {
+	for key, value := range metadata.stringFields() {
+		if !value.IsNull() {
+			card.set(key, value.ValueString())
+		}
+	}
+
+	if !metadata.Pinned.IsNull() {
+		card.set("pinned", metadata.Pinned.ValueBool())
+	}
+}
+
+// parseReadme splits a card into its frontmatter, as a YAML mapping node,
+// and its body.
+func parseReadme(readme []byte) (*yaml.Node, []byte, error) {
+	frontmatter := &yaml.Node{Kind: yaml.MappingNode}
+
+	rest, ok := bytes.CutPrefix(readme, []byte(frontmatterDelimiter))
+	if !ok {
+		return frontmatter, readme, nil
+	}
+
+	var raw []byte
+	if bytes.HasPrefix(rest, []byte(frontmatterDelimiter)) {
+		raw, rest = nil, rest[len(frontmatterDelimiter):]
+	} else {
+		end := bytes.Index(rest, []byte("\n"+frontmatterDelimiter))
+		if end < 0 {
+			return frontmatter, readme, nil
+		}
+		raw, rest = rest[:end+1], rest[end+1+len(frontmatterDelimiter):]
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(raw, &document); err != nil {
+		return nil, nil, fmt.Errorf("decoding card metadata: %w", err)
+	}
+	if len(document.Content) > 0 {
+		if document.Content[0].Kind != yaml.MappingNode {
+			return nil, nil, fmt.Errorf("decoding card metadata: expected a mapping")
+		}
+		frontmatter = document.Content[0]
+	}
+
+	return frontmatter, rest, nil
+}
+
+// mappingValue returns the value of key in mapping, or nil if it is missing.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setMappingValue sets key in mapping to value, appending the key if it is
+// missing.
+func setMappingValue(mapping *yaml.Node, key string, value interface{}) {
+	var node yaml.Node
+	_ = node.Encode(value)
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = &node
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+}
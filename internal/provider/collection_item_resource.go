@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CollectionItemResource{}
+	_ resource.ResourceWithConfigure   = &CollectionItemResource{}
+	_ resource.ResourceWithImportState = &CollectionItemResource{}
+)
+
+// collectionItemTypes lists the types of items a collection can hold.
+var collectionItemTypes = []string{"model", "dataset", "space", "paper"}
+
+// CollectionItemResource defines the resource implementation.
+type CollectionItemResource struct {
+	client *hfclient.Client
+}
+
+// CollectionItemResourceModel describes the resource data model.
+type CollectionItemResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	CollectionID types.String `tfsdk:"collection_id"`
+	ItemType     types.String `tfsdk:"item_type"`
+	ItemID       types.String `tfsdk:"item_id"`
+	Note         types.String `tfsdk:"note"`
+	Position     types.Int64  `tfsdk:"position"`
+}
+
+func (r *CollectionItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_item"
+}
+
+func (r *CollectionItemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an item of a collection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the item, in the form `collection_id/object_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collection_id": schema.StringAttribute{
+				MarkdownDescription: "The slug of the collection. Changing this forces a new item to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"item_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the item, one of `model`, `dataset`, `space` or `paper`. Changing this forces a new item to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(collectionItemTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`, or of the paper. Changing this forces a new item to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"note": schema.StringAttribute{
+				MarkdownDescription: "A note displayed next to the item.",
+				Optional:            true,
+			},
+			"position": schema.Int64Attribute{
+				MarkdownDescription: "The position of the item in the collection, starting at `0`. Defaults to the end of the collection.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *CollectionItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var in hfclient.AddCollectionItemRequest
+	in.Item.Type = data.ItemType.ValueString()
+	in.Item.ID = data.ItemID.ValueString()
+	in.Note = data.Note.ValueString()
+
+	collection, err := r.client.AddCollectionItem(ctx, data.CollectionID.ValueString(), in)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("add %s %s to collection", data.ItemType.ValueString(), data.ItemID.ValueString()), err)
+		return
+	}
+
+	// The API returns the whole collection, in which the new item is the
+	// one referring to the item that was added.
+	item := findCollectionItem(collection, func(item hfclient.CollectionItem) bool {
+		return item.Type == data.ItemType.ValueString() && item.ID == data.ItemID.ValueString()
+	})
+	if item == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Item %s was not found in collection %s after it was added", data.ItemID.ValueString(), data.CollectionID.ValueString()))
+		return
+	}
+
+	data.ID = types.StringValue(spaceKeyID(data.CollectionID.ValueString(), item.ObjectID))
+
+	if data.Position.IsUnknown() {
+		data.Position = types.Int64Value(item.Position)
+	} else if data.Position.ValueInt64() != item.Position {
+		position := data.Position.ValueInt64()
+		if err := r.client.UpdateCollectionItem(ctx, data.CollectionID.ValueString(), item.ObjectID, hfclient.UpdateCollectionItemRequest{Position: &position}); err != nil {
+			addClientError(&resp.Diagnostics, "update collection item position", err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, objectID, _ := splitSpaceKeyID(data.ID.ValueString())
+
+	collection, err := r.client.GetCollection(ctx, data.CollectionID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Collection %s not found, removing item %s from state", data.CollectionID.ValueString(), data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read collection", err)
+		return
+	}
+
+	item := findCollectionItem(collection, func(item hfclient.CollectionItem) bool {
+		return item.ObjectID == objectID
+	})
+	if item == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Collection item %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ItemType = types.StringValue(item.Type)
+	data.ItemID = types.StringValue(item.ID)
+	data.Position = types.Int64Value(item.Position)
+	if item.Note != nil && item.Note.Text != "" {
+		data.Note = types.StringValue(item.Note.Text)
+	} else if !data.Note.IsNull() {
+		data.Note = types.StringValue("")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, objectID, _ := splitSpaceKeyID(data.ID.ValueString())
+
+	note := data.Note.ValueString()
+	in := hfclient.UpdateCollectionItemRequest{
+		Note: &note,
+	}
+	if !data.Position.IsUnknown() {
+		in.Position = data.Position.ValueInt64Pointer()
+	}
+
+	if err := r.client.UpdateCollectionItem(ctx, data.CollectionID.ValueString(), objectID, in); err != nil {
+		addClientError(&resp.Diagnostics, "update collection item", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, objectID, _ := splitSpaceKeyID(data.ID.ValueString())
+
+	// An item whose collection is already gone is as good as deleted.
+	err := r.client.DeleteCollectionItem(ctx, data.CollectionID.ValueString(), objectID)
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete collection item", err)
+		return
+	}
+}
+
+func (r *CollectionItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	collectionID, _, ok := splitSpaceKeyID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form collection_id/object_id, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_id"), collectionID)...)
+}
+
+// findCollectionItem returns the first item of collection matching match, or
+// nil if there is none.
+func findCollectionItem(collection *hfclient.Collection, match func(hfclient.CollectionItem) bool) *hfclient.CollectionItem {
+	for i := range collection.Items {
+		if match(collection.Items[i]) {
+			return &collection.Items[i]
+		}
+	}
+
+	return nil
+}
+
+func NewCollectionItemResource() resource.Resource {
+	return &CollectionItemResource{}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CollectionResource{}
+	_ resource.ResourceWithConfigure   = &CollectionResource{}
+	_ resource.ResourceWithImportState = &CollectionResource{}
+)
+
+// CollectionResource defines the resource implementation.
+type CollectionResource struct {
+	client *hfclient.Client
+}
+
+// CollectionResourceModel describes the resource data model.
+type CollectionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Namespace   types.String `tfsdk:"namespace"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Private     types.Bool   `tfsdk:"private"`
+	URL         types.String `tfsdk:"url"`
+}
+
+func (r *CollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a collection of models, datasets, spaces and papers. Items are managed with `huggingface-spaces_collection_item`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The slug of the collection, in the form `namespace/title-id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The user or organization the collection belongs to. Defaults to the owner of the token. Changing this forces a new collection to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The title of the collection.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the collection.",
+				Optional:            true,
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the collection is only visible to its owner. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the collection on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Collections are always created in a namespace, so it is resolved from
+	// the token when not configured.
+	if data.Namespace.IsUnknown() {
+		whoami, err := r.client.WhoAmI(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read authenticated user", err)
+			return
+		}
+		data.Namespace = types.StringValue(whoami.Name)
+	}
+
+	collection, err := r.client.CreateCollection(ctx, hfclient.CreateCollectionRequest{
+		Title:       data.Title.ValueString(),
+		Namespace:   data.Namespace.ValueString(),
+		Description: data.Description.ValueString(),
+		Private:     data.Private.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create collection", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created collection %s", collection.Slug))
+
+	data.ID = types.StringValue(collection.Slug)
+	data.URL = types.StringValue(r.collectionURL(collection.Slug))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.GetCollection(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Collection %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read collection", err)
+		return
+	}
+
+	data.Namespace = types.StringValue(collection.Owner.Name)
+	data.Title = types.StringValue(collection.Title)
+	data.Private = types.BoolValue(collection.Private)
+	data.URL = types.StringValue(r.collectionURL(collection.Slug))
+	if !data.Description.IsNull() || (collection.Description != nil && *collection.Description != "") {
+		data.Description = types.StringPointerValue(collection.Description)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	description := data.Description.ValueString()
+	err := r.client.UpdateCollection(ctx, data.ID.ValueString(), hfclient.UpdateCollectionRequest{
+		Title:       data.Title.ValueStringPointer(),
+		Description: &description,
+		Private:     data.Private.ValueBoolPointer(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update collection", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteCollection(ctx, data.ID.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete collection", err)
+		return
+	}
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// collectionURL returns the URL of the collection slug on the Hub.
+func (r *CollectionResource) collectionURL(slug string) string {
+	return strings.TrimRight(r.client.Endpoint(), "/") + "/collections/" + slug
+}
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
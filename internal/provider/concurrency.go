@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/sync/errgroup"
+)
+
+// concurrentCall is a single API request run by runConcurrently.
+type concurrentCall struct {
+	// action describes the request in diagnostics, such as "set secret FOO".
+	action string
+	run    func(ctx context.Context) error
+}
+
+// runConcurrently runs calls with at most limit of them in flight. A failing
+// call does not cancel the others, so that every failure is reported: an
+// error diagnostic is added for each of them, in the order of calls.
+func runConcurrently(ctx context.Context, limit int, calls []concurrentCall, diags *diag.Diagnostics) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	errs := make([]error, len(calls))
+
+	var group errgroup.Group
+	group.SetLimit(limit)
+	for i, call := range calls {
+		group.Go(func() error {
+			errs[i] = call.run(ctx)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			addClientError(diags, calls[i].action, err)
+		}
+	}
+}
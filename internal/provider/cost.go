@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// hoursPerMonth is the number of hours monthly prices are spread over.
+const hoursPerMonth = 730
+
+// storageMonthlyCostUSD maps the persistent storage tiers to their price in
+// USD per month, which the API does not expose.
+var storageMonthlyCostUSD = map[string]float64{
+	"small":  5,
+	"medium": 25,
+	"large":  100,
+}
+
+// hardwarePrices caches the hourly price of the hardware flavors, which is
+// fetched from the API at most once per provider process. Failures are not
+// cached, so that a transient error does not disable cost estimates for the
+// rest of the process.
+type hardwarePrices struct {
+	mu     sync.Mutex
+	prices map[string]float64
+}
+
+// get returns the price in USD per hour of each hardware flavor.
+func (p *hardwarePrices) get(ctx context.Context, client *hfclient.Client) (map[string]float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.prices != nil {
+		return p.prices, nil
+	}
+
+	flavors, err := client.ListSpaceHardware(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(flavors))
+	for _, flavor := range flavors {
+		switch flavor.UnitLabel {
+		case "hour":
+			prices[flavor.Name] = flavor.UnitCostUSD
+		case "minute":
+			prices[flavor.Name] = flavor.UnitCostUSD * 60
+		}
+	}
+	p.prices = prices
+
+	return p.prices, nil
+}
+
+// estimateHourlyCost estimates the price in USD per hour of running the space
+// of data on its requested hardware with its storage. The estimate is unknown
+// while the hardware is, and null if the price of the hardware is not known.
+func (r *SpaceResource) estimateHourlyCost(ctx context.Context, data *SpaceResourceModel) types.Float64 {
+	// Nothing can be fetched before the provider is configured.
+	if r.prices == nil || data.Hardware.IsUnknown() || data.ScheduledHardware.IsUnknown() || data.Storage.IsUnknown() {
+		return types.Float64Unknown()
+	}
+
+	// Spaces without hardware run on the free hardware the Hub defaults to.
+	hardware := requestedHardware(data)
+	if data.Hardware.IsNull() && data.ScheduledHardware.IsNull() {
+		hardware = "cpu-basic"
+	}
+
+	prices, err := r.prices.get(ctx, r.client)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to retrieve hardware prices, not estimating cost: %s", err))
+		return types.Float64Null()
+	}
+
+	cost, ok := prices[hardware]
+	if !ok {
+		tflog.Debug(ctx, fmt.Sprintf("No price known for hardware %s, not estimating cost", hardware))
+		return types.Float64Null()
+	}
+	cost += storageMonthlyCostUSD[data.Storage.ValueString()] / hoursPerMonth
+
+	return types.Float64Value(math.Round(cost*10000) / 10000)
+}
+
+// planSpaceCost plans the estimated cost of a space, warning reviewers when
+// it changes. The price of hardware is only fetched when the hardware or
+// storage of the space changes.
+func (r *SpaceResource) planSpaceCost(ctx context.Context, state *SpaceResourceModel, plan *SpaceResourceModel, resp *resource.ModifyPlanResponse) {
+	if state != nil && !state.EstimatedHourlyCost.IsNull() &&
+		requestedHardware(state) == requestedHardware(plan) && state.Storage.Equal(plan.Storage) {
+		plan.EstimatedHourlyCost = state.EstimatedHourlyCost
+	} else {
+		plan.EstimatedHourlyCost = r.estimateHourlyCost(ctx, plan)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_hourly_cost"), plan.EstimatedHourlyCost)...)
+
+	if plan.EstimatedHourlyCost.IsNull() || plan.EstimatedHourlyCost.IsUnknown() {
+		return
+	}
+
+	cost := plan.EstimatedHourlyCost.ValueFloat64()
+	switch {
+	case state == nil && cost > 0:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("estimated_hourly_cost"),
+			"Estimated Space Cost",
+			fmt.Sprintf("The space will cost an estimated $%.2f per hour, or $%.2f per month if it never sleeps, for its hardware and storage.", cost, cost*hoursPerMonth),
+		)
+	case state != nil && !state.EstimatedHourlyCost.IsNull() && state.EstimatedHourlyCost.ValueFloat64() != cost:
+		previous := state.EstimatedHourlyCost.ValueFloat64()
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("estimated_hourly_cost"),
+			"Estimated Space Cost Changes",
+			fmt.Sprintf("Space %s will cost an estimated $%.2f per hour instead of $%.2f, a change of $%+.2f per month if it never sleeps.", state.ID.ValueString(), cost, previous, (cost-previous)*hoursPerMonth),
+		)
+	}
+}
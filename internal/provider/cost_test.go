@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+func TestSpaceResourceCostRetriedAfterError(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	// Listing the hardware fails once.
+	var failed bool
+	hub.Intercept(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != "/api/spaces/hardware" || failed {
+			return false
+		}
+		failed = true
+		http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+		return true
+	})
+
+	config := map[string]attr.Value{
+		"name":     types.StringValue("priced"),
+		"sdk":      types.StringValue("gradio"),
+		"hardware": types.StringValue("cpu-upgrade"),
+	}
+
+	requireNoErrors(t, "create", space.apply(config))
+	if cost := space.attribute("estimated_hourly_cost"); !cost.IsNull() {
+		t.Errorf("estimated_hourly_cost = %s without prices, expected null", cost)
+	}
+
+	// The next plan of the same provider fetches the prices again.
+	planned, _, diags := space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	var cost big.Float
+	if err := attributeValue(t, planned, "estimated_hourly_cost").As(&cost); err != nil {
+		t.Fatal(err)
+	}
+	if actual, _ := cost.Float64(); actual != 0.03 {
+		t.Errorf("estimated_hourly_cost = %v, expected 0.03", actual)
+	}
+
+	// Prices that were fetched are cached.
+	_, _, diags = space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if requests := hub.Requests(http.MethodGet, "/api/spaces/hardware"); len(requests) != 2 {
+		t.Errorf("listed the hardware %d times, expected 2", len(requests))
+	}
+}
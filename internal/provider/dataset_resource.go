@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatasetResource{}
+	_ resource.ResourceWithConfigure   = &DatasetResource{}
+	_ resource.ResourceWithImportState = &DatasetResource{}
+	_ resource.ResourceWithModifyPlan  = &DatasetResource{}
+)
+
+// DatasetResource defines the resource implementation.
+type DatasetResource struct {
+	client *hfclient.Client
+}
+
+// DatasetResourceModel describes the resource data model.
+type DatasetResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Namespace    types.String `tfsdk:"namespace"`
+	Private      types.Bool   `tfsdk:"private"`
+	Gated        types.String `tfsdk:"gated"`
+	Gating       types.Object `tfsdk:"gating"`
+	SHA          types.String `tfsdk:"sha"`
+	LastModified types.String `tfsdk:"last_modified"`
+}
+
+func (r *DatasetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset"
+}
+
+func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a dataset repository on the Hugging Face Hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the dataset, in the form `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the dataset. Changing this renames the dataset, which keeps its contents and settings.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The user or organization the dataset belongs to. Defaults to the owner of the token. Changing this forces a new dataset to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					// Imported datasets have the namespace of their ID in
+					// state, which an unconfigured namespace must keep.
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the dataset is private.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"gated":  gatedAttribute("dataset"),
+			"gating": gatingAttribute("dataset"),
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the latest commit on the main branch.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "When the dataset was last modified.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DatasetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *DatasetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRepoRename(ctx, hfclient.RepoTypeDataset, req, resp)
+}
+
+func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := hfclient.CreateRepoRequest{
+		Type:         hfclient.RepoTypeDataset,
+		Name:         data.Name.ValueString(),
+		Organization: data.Namespace.ValueString(),
+	}
+	if !data.Private.IsUnknown() {
+		createReq.Private = data.Private.ValueBoolPointer()
+	}
+
+	created, err := r.client.CreateRepo(ctx, createReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create dataset", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create Dataset Response: %+v", *created))
+
+	datasetName := created.Name
+	if datasetName == "" {
+		resp.Diagnostics.AddError("Invalid Response", "Unable to extract dataset name from create dataset response")
+		return
+	}
+
+	data.ID = types.StringValue(datasetName)
+
+	// Gating can only be configured once the dataset exists
+	if !data.Gated.IsUnknown() && data.Gated.ValueString() != gatedDisabled {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeDataset, data.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	writeRepoGating(ctx, r.client, hfclient.RepoTypeDataset, data.ID.ValueString(), data.Gating, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readDataset(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Dataset %s was not found after it was created", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DatasetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readDataset(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("Dataset %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DatasetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the dataset needs to be renamed
+	if toRepo := repoRenameTarget(state.ID.ValueString(), data.Name.ValueString()); toRepo != state.ID.ValueString() {
+		renameRepo(ctx, r.client, hfclient.RepoTypeDataset, state.ID.ValueString(), toRepo, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.ID = types.StringValue(toRepo)
+		state.Name = data.Name
+	}
+
+	// Check if the dataset visibility needs to be updated
+	if !data.Private.IsUnknown() && !data.Private.Equal(state.Private) {
+		setRepoPrivate(ctx, r.client, hfclient.RepoTypeDataset, state.ID.ValueString(), data.Private.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Private = data.Private
+	}
+
+	// Check if the dataset gating needs to be updated
+	if !data.Gated.IsUnknown() && state.Gated.ValueString() != data.Gated.ValueString() {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeDataset, state.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Gated = data.Gated
+	}
+
+	// Check if the gating form needs to be updated
+	if !data.Gating.IsUnknown() && !data.Gating.Equal(state.Gating) {
+		writeRepoGating(ctx, r.client, hfclient.RepoTypeDataset, state.ID.ValueString(), data.Gating, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Gating = data.Gating
+	}
+
+	found := r.readDataset(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Dataset %s was not found after it was updated", state.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatasetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DatasetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteRepo(ctx, r.client, hfclient.RepoTypeDataset, data.ID.ValueString(), &resp.Diagnostics)
+}
+
+func (r *DatasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRepoState(ctx, req, resp)
+}
+
+// readDataset refreshes data with the dataset as returned by the API. It reports
+// whether the dataset exists.
+func (r *DatasetResource) readDataset(ctx context.Context, data *DatasetResourceModel, diags *diag.Diagnostics) bool {
+	responseData, err := r.client.GetDataset(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		addClientError(diags, "read dataset", err)
+		return false
+	}
+
+	namespace, _ := splitRepoID(data.ID.ValueString())
+	data.Namespace = types.StringValue(namespace)
+	if responseData.Private != nil {
+		data.Private = types.BoolValue(*responseData.Private)
+	}
+	data.Gated = types.StringValue(gatedMode(responseData.Gated))
+	data.Gating = readRepoGating(ctx, r.client, hfclient.RepoTypeDataset, data.ID.ValueString(), data.Gating, diags)
+	data.SHA = types.StringPointerValue(responseData.SHA)
+	data.LastModified = types.StringPointerValue(responseData.LastModified)
+
+	return true
+}
+
+func NewDatasetResource() resource.Resource {
+	return &DatasetResource{}
+}
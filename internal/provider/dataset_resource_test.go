@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+// testDatasetType is the type name of the dataset resource.
+const testDatasetType = "huggingface-spaces_dataset"
+
+func TestDatasetResourceLifecycle(t *testing.T) {
+	hub := testutil.NewHub(t)
+	dataset := newHubProvider(t, hub).resource(testDatasetType)
+
+	config := map[string]attr.Value{
+		"name":    types.StringValue("reviews"),
+		"private": types.BoolValue(true),
+	}
+	requireNoErrors(t, "create", dataset.apply(config))
+
+	if created := hub.Repo(hfclient.RepoTypeDataset, "testutil/reviews"); created == nil || !created.Private {
+		t.Fatalf("dataset was created as %+v", created)
+	}
+	if !dataset.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	config["name"] = types.StringValue("movie-reviews")
+	config["private"] = types.BoolValue(false)
+	_, requiresReplace, diags := dataset.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Fatalf("renaming requires replacing %v", requiresReplace)
+	}
+	requireNoErrors(t, "update", dataset.apply(config))
+
+	if hub.Repo(hfclient.RepoTypeDataset, "testutil/reviews") != nil {
+		t.Error("dataset was not renamed")
+	}
+	if renamed := hub.Repo(hfclient.RepoTypeDataset, "testutil/movie-reviews"); renamed == nil || renamed.Private {
+		t.Errorf("dataset was updated to %+v", renamed)
+	}
+	if requests := hub.Requests(http.MethodDelete, "/api/repos/delete"); len(requests) != 0 {
+		t.Errorf("sent %d delete requests, expected the dataset to be updated in place", len(requests))
+	}
+	if !dataset.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+
+	imported := newHubProvider(t, hub).resource(testDatasetType)
+	requireNoErrors(t, "import", imported.importState("testutil/movie-reviews"))
+	for _, name := range []string{"id", "name", "namespace", "private", "sha"} {
+		if !imported.attribute(name).Equal(dataset.attribute(name)) {
+			t.Errorf("imported %s = %s, expected %s", name, imported.attribute(name), dataset.attribute(name))
+		}
+	}
+	if !imported.planIsEmpty(config) {
+		t.Error("plan is not empty after import")
+	}
+
+	requireNoErrors(t, "destroy", dataset.destroy())
+	if hub.Repo(hfclient.RepoTypeDataset, "testutil/movie-reviews") != nil {
+		t.Error("dataset was not deleted")
+	}
+}
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DiscussionResource{}
+	_ resource.ResourceWithConfigure   = &DiscussionResource{}
+	_ resource.ResourceWithImportState = &DiscussionResource{}
+)
+
+// DiscussionResource defines the resource implementation.
+type DiscussionResource struct {
+	client *hfclient.Client
+}
+
+// DiscussionResourceModel describes the resource data model.
+type DiscussionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	RepoID      types.String `tfsdk:"repo_id"`
+	RepoType    types.String `tfsdk:"repo_type"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	PullRequest types.Bool   `tfsdk:"pull_request"`
+	Num         types.Int64  `tfsdk:"num"`
+	Status      types.String `tfsdk:"status"`
+	URL         types.String `tfsdk:"url"`
+}
+
+func (r *DiscussionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discussion"
+}
+
+func (r *DiscussionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Opens a discussion, or a pull request, on a space, model or dataset repository, such as an announcement posted after each deployment. Destroying the resource closes the discussion, as only repository admins can delete discussions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the discussion, in the form `repo_type:namespace/name@num`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`. Changing this forces a new discussion to be opened.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`. Changing this forces a new discussion to be opened.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hfclient.RepoTypeSpace)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The title of the discussion.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(3),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The body of the discussion, in Markdown, posted as its first comment. Changing this forces a new discussion to be opened.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pull_request": schema.BoolAttribute{
+				MarkdownDescription: "Whether a draft pull request is opened rather than a discussion. Changes are pushed to its `refs/pr/<num>` ref. Defaults to `false`. Changing this forces a new discussion to be opened.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"num": schema.Int64Attribute{
+				MarkdownDescription: "The number of the discussion in the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the discussion, one of `open` or `closed`, or `draft` or `merged` for pull requests.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the discussion on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DiscussionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *DiscussionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DiscussionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Opening discussion %q on %s", data.Title.ValueString(), data.RepoID.ValueString()))
+
+	discussion, err := r.client.CreateDiscussion(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), hfclient.CreateDiscussionRequest{
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		PullRequest: data.PullRequest.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "open discussion", err)
+		return
+	}
+
+	data.Num = types.Int64Value(discussion.Num)
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), strconv.FormatInt(discussion.Num, 10)))
+	data.URL = types.StringValue(r.discussionURL(data))
+
+	// The status is not always part of the response to the create request.
+	data.Status = types.StringValue(discussion.Status)
+	if discussion.Status == "" {
+		data.Status = types.StringValue("open")
+		if data.PullRequest.ValueBool() {
+			data.Status = types.StringValue("draft")
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscussionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DiscussionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	discussion, err := r.client.GetDiscussion(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Num.ValueInt64())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Discussion %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read discussion", err)
+		return
+	}
+
+	data.Title = types.StringValue(discussion.Title)
+	if len(discussion.Events) > 0 && discussion.Events[0].Type == "comment" {
+		data.Description = types.StringValue(discussion.Events[0].Data.Latest.Raw)
+	}
+	data.PullRequest = types.BoolValue(discussion.IsPullRequest)
+	data.Status = types.StringValue(discussion.Status)
+	data.URL = types.StringValue(r.discussionURL(data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscussionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *DiscussionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the title can change in place.
+	if !data.Title.Equal(state.Title) {
+		tflog.Debug(ctx, fmt.Sprintf("Renaming discussion %s to %q", data.ID.ValueString(), data.Title.ValueString()))
+
+		err := r.client.RenameDiscussion(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Num.ValueInt64(), data.Title.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, "rename discussion", err)
+			return
+		}
+	}
+
+	data.Status = state.Status
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscussionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DiscussionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Merged pull requests and closed discussions are left as they are.
+	if data.Status.ValueString() == "closed" || data.Status.ValueString() == "merged" {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Closing discussion %s", data.ID.ValueString()))
+
+	err := r.client.SetDiscussionStatus(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Num.ValueInt64(), "closed")
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "close discussion", err)
+		return
+	}
+}
+
+func (r *DiscussionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, ref, ok := splitRepoRefID(req.ID)
+	num, err := strconv.ParseInt(ref, 10, 64)
+	if !ok || err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form repo_type:namespace/name@num, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("num"), num)...)
+}
+
+// discussionURL returns the URL of the discussion of data on the Hub. Model
+// URLs have no prefix, unlike those of spaces and datasets.
+func (r *DiscussionResource) discussionURL(data *DiscussionResourceModel) string {
+	prefix := ""
+	if repoType := hfclient.RepoType(data.RepoType.ValueString()); repoType != hfclient.RepoTypeModel {
+		prefix = "/" + string(repoType) + "s"
+	}
+
+	return fmt.Sprintf("%s%s/%s/discussions/%d", strings.TrimRight(r.client.Endpoint(), "/"), prefix, data.RepoID.ValueString(), data.Num.ValueInt64())
+}
+
+func NewDiscussionResource() resource.Resource {
+	return &DiscussionResource{}
+}
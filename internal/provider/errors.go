@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// addClientError reports err, returned by the Hub client while trying to
+// action, as an error diagnostic. API errors include the message of the API
+// and, where one applies, a hint on how to resolve them.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	var apiErr *hfclient.APIError
+	if errors.As(err, &apiErr) {
+		detail := fmt.Sprintf("Unable to %s, got %s", action, apiErr)
+		if hint := apiErrorHint(apiErr); hint != "" {
+			detail += "\n\n" + hint
+		}
+
+		diags.AddError("API Error", detail)
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+}
+
+// apiErrorHint returns a hint on how to resolve apiErr, or an empty string if
+// there is none.
+func apiErrorHint(apiErr *hfclient.APIError) string {
+	message := strings.ToLower(apiErr.Message)
+
+	switch {
+	case apiErr.StatusCode == http.StatusPaymentRequired || strings.Contains(message, "payment method"):
+		return "The requested hardware or feature requires a payment method on the account that owns it. Add one at https://huggingface.co/settings/billing."
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return "Check that the provider token is valid and has not been revoked."
+	case apiErr.StatusCode == http.StatusForbidden:
+		return "Check that the provider token has write access to the namespace, and that fine-grained tokens are granted the permissions this operation requires."
+	case apiErr.StatusCode == http.StatusNotFound:
+		return "Check that it exists and is visible to the provider token. Private repositories are reported as not found to tokens without access to them."
+	case apiErr.StatusCode == http.StatusConflict:
+		return "A repository or setting with this name already exists."
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		return "The API rate limit was exceeded even after retrying. Try again later, or raise max_retries."
+	}
+
+	return ""
+}
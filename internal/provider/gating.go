@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// gatedDisabled is the gated value of a repository anyone can access.
+const gatedDisabled = "disabled"
+
+// gatedMode returns the gating mode of a repository from the gated field of
+// an API response, which is false when gating is disabled.
+func gatedMode(gated interface{}) string {
+	if mode, ok := gated.(string); ok {
+		return mode
+	}
+	return gatedDisabled
+}
+
+// gatedModes lists the gating modes of a repository.
+var gatedModes = []string{"auto", "manual", gatedDisabled}
+
+// gatingFieldTypes lists the types of the fields users fill in to request
+// access to a gated repository.
+var gatingFieldTypes = []string{"text", "checkbox", "date_picker", "country"}
+
+// RepoGatingModel describes the gating attribute.
+type RepoGatingModel struct {
+	Heading       types.String `tfsdk:"heading"`
+	Prompt        types.String `tfsdk:"prompt"`
+	Description   types.String `tfsdk:"description"`
+	ButtonContent types.String `tfsdk:"button_content"`
+	Fields        types.Map    `tfsdk:"fields"`
+}
+
+// gatingAttrTypes are the attribute types of the gating object.
+var gatingAttrTypes = map[string]attr.Type{
+	"heading":        types.StringType,
+	"prompt":         types.StringType,
+	"description":    types.StringType,
+	"button_content": types.StringType,
+	"fields":         types.MapType{ElemType: types.StringType},
+}
+
+// stringFields maps the frontmatter keys of the string attributes of m to
+// their values.
+func (m *RepoGatingModel) stringFields() map[string]*types.String {
+	return map[string]*types.String{
+		"extra_gated_heading":        &m.Heading,
+		"extra_gated_prompt":         &m.Prompt,
+		"extra_gated_description":    &m.Description,
+		"extra_gated_button_content": &m.ButtonContent,
+	}
+}
+
+// gatedAttribute returns the gated attribute of a repository resource of
+// kind, such as model.
+func gatedAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Whether users must request access to the %s, one of `auto` (requests are approved automatically), `manual` or `disabled`.", kind),
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(gatedModes...),
+		},
+	}
+}
+
+// gatingAttribute returns the gating attribute of a repository resource of
+// kind, such as model.
+func gatingAttribute(kind string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("The form users fill in to request access to the %s while it is gated, rendered into the YAML frontmatter of its `README.md`. Keys that are not set here are left as they are.", kind),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"heading": schema.StringAttribute{
+				MarkdownDescription: "The heading of the form.",
+				Optional:            true,
+			},
+			"prompt": schema.StringAttribute{
+				MarkdownDescription: "Custom text shown above the form, such as the terms users agree to.",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description shown below the heading of the form.",
+				Optional:            true,
+			},
+			"button_content": schema.StringAttribute{
+				MarkdownDescription: "The label of the button submitting the form.",
+				Optional:            true,
+			},
+			"fields": schema.MapAttribute{
+				MarkdownDescription: "Extra fields of the form, mapping their labels to their types, one of `" + strings.Join(gatingFieldTypes, "`, `") + "`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(gatingFieldTypes...)),
+				},
+			},
+		},
+	}
+}
+
+// repoGating returns the gating attribute, or nil if it is not set.
+func repoGating(ctx context.Context, object types.Object, diags *diag.Diagnostics) *RepoGatingModel {
+	if object.IsNull() || object.IsUnknown() {
+		return nil
+	}
+
+	var gating RepoGatingModel
+	diags.Append(object.As(ctx, &gating, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &gating
+}
+
+// writeCardGating sets the attributes of gating that are set in card.
+func writeCardGating(ctx context.Context, card *repoCard, gating *RepoGatingModel, diags *diag.Diagnostics) {
+	for key, value := range gating.stringFields() {
+		if !value.IsNull() {
+			card.set(key, value.ValueString())
+		}
+	}
+
+	if !gating.Fields.IsNull() {
+		var fields map[string]string
+		diags.Append(gating.Fields.ElementsAs(ctx, &fields, false)...)
+		card.set("extra_gated_fields", fields)
+	}
+}
+
+// readCardGating refreshes the attributes of gating that are set from card.
+// Attributes that are not set are not managed and left alone.
+func readCardGating(ctx context.Context, card *repoCard, gating *RepoGatingModel, diags *diag.Diagnostics) {
+	for key, value := range gating.stringFields() {
+		if value.IsNull() {
+			continue
+		}
+
+		*value = types.StringNull()
+		if node := card.get(key); node != nil {
+			*value = types.StringValue(node.Value)
+		}
+	}
+
+	if !gating.Fields.IsNull() {
+		gating.Fields = types.MapNull(types.StringType)
+
+		// Fields are either declared by their type, or as an object with a
+		// type and options for select fields.
+		var raw map[string]interface{}
+		if node := card.get("extra_gated_fields"); node != nil && node.Decode(&raw) == nil {
+			fields := make(map[string]string, len(raw))
+			for label, field := range raw {
+				switch field := field.(type) {
+				case string:
+					fields[label] = field
+				case map[string]interface{}:
+					fields[label], _ = field["type"].(string)
+				}
+			}
+
+			value, d := types.MapValueFrom(ctx, types.StringType, fields)
+			diags.Append(d...)
+			gating.Fields = value
+		}
+	}
+}
+
+// setRepoGated updates the gating mode of a repository.
+func setRepoGated(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, gated string, diags *diag.Diagnostics) {
+	// The API expects false rather than a mode to disable gating.
+	var mode interface{} = gated
+	if gated == gatedDisabled {
+		mode = false
+	}
+
+	err := client.UpdateRepoSettings(ctx, repoType, repoID, hfclient.RepoSettings{
+		Gated: mode,
+	})
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("update %s gating", repoType), err)
+	}
+}
+
+// writeRepoGating renders the gating attribute into the card of a repository,
+// committing the card if it changed.
+func writeRepoGating(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, object types.Object, diags *diag.Diagnostics) {
+	gating := repoGating(ctx, object, diags)
+	if diags.HasError() || gating == nil {
+		return
+	}
+
+	card, err := loadRepoCard(ctx, client, repoType, repoID)
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("read %s card", repoType), err)
+		return
+	}
+
+	writeCardGating(ctx, card, gating, diags)
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating gating form of %s %s", repoType, repoID))
+
+	if err := card.save(ctx, client, repoType, repoID); err != nil {
+		addClientError(diags, fmt.Sprintf("update %s card", repoType), err)
+	}
+}
+
+// readRepoGating refreshes the gating attribute from the card of a
+// repository. Only what is managed is read back.
+func readRepoGating(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, object types.Object, diags *diag.Diagnostics) types.Object {
+	gating := repoGating(ctx, object, diags)
+	if diags.HasError() || gating == nil {
+		return object
+	}
+
+	card, err := loadRepoCard(ctx, client, repoType, repoID)
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("read %s card", repoType), err)
+		return object
+	}
+
+	readCardGating(ctx, card, gating, diags)
+
+	value, d := types.ObjectValueFrom(ctx, gatingAttrTypes, gating)
+	diags.Append(d...)
+	if diags.HasError() {
+		return object
+	}
+
+	return value
+}
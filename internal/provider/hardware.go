@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// zeroGPUHardware is the flavor of ZeroGPU spaces, which share GPUs that are
+// allocated on demand and are only available to Gradio spaces.
+const zeroGPUHardware = "zero-a10g"
+
+// spaceHardwareFlavors lists the hardware flavors spaces can request.
+var spaceHardwareFlavors = []string{
+	"cpu-basic",
+	"cpu-upgrade",
+	"cpu-xl",
+	"zero-a10g",
+	"t4-small",
+	"t4-medium",
+	"l4x1",
+	"l4x4",
+	"l40sx1",
+	"l40sx4",
+	"l40sx8",
+	"a10g-small",
+	"a10g-large",
+	"a10g-largex2",
+	"a10g-largex4",
+	"a100-large",
+	"h100",
+	"h100x8",
+}
+
+// hardwareAliases maps commonly used shorthands to their hardware flavor.
+var hardwareAliases = map[string]string{
+	"cpu":     "cpu-basic",
+	"zerogpu": "zero-a10g",
+	"zero":    "zero-a10g",
+	"t4":      "t4-small",
+	"l4":      "l4x1",
+	"l40s":    "l40sx1",
+	"a10g":    "a10g-small",
+	"a100":    "a100-large",
+}
+
+// normalizeHardware maps a user supplied hardware value such as "A10G Small"
+// to its canonical flavor, reporting whether the value is a known flavor.
+func normalizeHardware(hardware string) (string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(hardware))
+	normalized = strings.NewReplacer(" ", "-", "_", "-").Replace(normalized)
+
+	if flavor, ok := hardwareAliases[normalized]; ok {
+		normalized = flavor
+	}
+
+	for _, flavor := range spaceHardwareFlavors {
+		if flavor == normalized {
+			return normalized, true
+		}
+	}
+
+	return normalized, false
+}
+
+// canonicalHardware returns the canonical flavor for hardware, or hardware
+// itself if it is not a known flavor.
+func canonicalHardware(hardware string) string {
+	flavor, _ := normalizeHardware(hardware)
+	return flavor
+}
+
+// hardwareValidator validates that a string is a known hardware flavor, in
+// any of the spellings normalizeHardware accepts.
+type hardwareValidator struct{}
+
+var _ validator.String = hardwareValidator{}
+
+func (v hardwareValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a known hardware flavor, one of: %s", strings.Join(spaceHardwareFlavors, ", "))
+}
+
+func (v hardwareValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hardwareValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := normalizeHardware(req.ConfigValue.ValueString()); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Unknown Hardware Flavor",
+			fmt.Sprintf("Hardware %q is not a known flavor, expected one of: %s.", req.ConfigValue.ValueString(), strings.Join(spaceHardwareFlavors, ", ")),
+		)
+	}
+}
+
+// addHardwareError reports err, returned by the Hub client while trying to
+// action with hardware requested, as an error diagnostic. The API answers
+// ZeroGPU requests exceeding the quota of the owner, or made by owners not
+// entitled to ZeroGPU at all, with a 4xx, which is reported on the hardware
+// attribute along with what can be done about it.
+func addHardwareError(diags *diag.Diagnostics, action string, hardware string, err error) {
+	code := hfclient.StatusCode(err)
+	if hardware != zeroGPUHardware || (code != http.StatusPaymentRequired && code != http.StatusForbidden && code != http.StatusTooManyRequests) {
+		addClientError(diags, action, err)
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("hardware"),
+		"ZeroGPU Not Available",
+		fmt.Sprintf("Unable to %s on ZeroGPU hardware, got %s\n\n"+
+			"ZeroGPU spaces can only be hosted by PRO users and Team or Enterprise organizations, which can each host a limited number of them. "+
+			"Upgrade the plan of the owner of the space, move another of its spaces off ZeroGPU hardware, or request different hardware.", action, err),
+	)
+}
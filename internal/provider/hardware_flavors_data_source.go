@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &HardwareFlavorsDataSource{}
+
+// HardwareFlavorsDataSource defines the data source implementation.
+type HardwareFlavorsDataSource struct {
+	client *hfclient.Client
+}
+
+// HardwareFlavorsDataSourceModel describes the data source data model.
+type HardwareFlavorsDataSourceModel struct {
+	Names   types.List            `tfsdk:"names"`
+	Flavors []HardwareFlavorModel `tfsdk:"flavors"`
+}
+
+// HardwareFlavorModel describes a single hardware flavor.
+type HardwareFlavorModel struct {
+	Name        types.String  `tfsdk:"name"`
+	PrettyName  types.String  `tfsdk:"pretty_name"`
+	CPU         types.String  `tfsdk:"cpu"`
+	RAM         types.String  `tfsdk:"ram"`
+	Accelerator types.String  `tfsdk:"accelerator"`
+	HourlyPrice types.Float64 `tfsdk:"hourly_price"`
+}
+
+func (d *HardwareFlavorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hardware_flavors"
+}
+
+func (d *HardwareFlavorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the hardware flavors spaces can currently run on, along with their price.",
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the hardware flavors, as accepted by the `hardware` attribute of a space.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"flavors": schema.ListNestedAttribute{
+				MarkdownDescription: "The hardware flavors.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the flavor, such as `t4-small`.",
+							Computed:            true,
+						},
+						"pretty_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the flavor.",
+							Computed:            true,
+						},
+						"cpu": schema.StringAttribute{
+							MarkdownDescription: "The CPUs of the flavor.",
+							Computed:            true,
+						},
+						"ram": schema.StringAttribute{
+							MarkdownDescription: "The memory of the flavor.",
+							Computed:            true,
+						},
+						"accelerator": schema.StringAttribute{
+							MarkdownDescription: "The accelerator model of the flavor, such as `T4`, or null for CPU only flavors.",
+							Computed:            true,
+						},
+						"hourly_price": schema.Float64Attribute{
+							MarkdownDescription: "The price in USD of running a space on the flavor for an hour.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HardwareFlavorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *HardwareFlavorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HardwareFlavorsDataSourceModel
+
+	flavors, err := d.client.ListSpaceHardware(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list hardware flavors", err)
+		return
+	}
+
+	names := []string{}
+	data.Flavors = []HardwareFlavorModel{}
+	for _, flavor := range flavors {
+		names = append(names, flavor.Name)
+
+		accelerator := types.StringNull()
+		if flavor.Accelerator != nil {
+			accelerator = types.StringValue(flavor.Accelerator.Model)
+		}
+
+		data.Flavors = append(data.Flavors, HardwareFlavorModel{
+			Name:        types.StringValue(flavor.Name),
+			PrettyName:  types.StringValue(flavor.PrettyName),
+			CPU:         types.StringValue(flavor.CPU),
+			RAM:         types.StringValue(flavor.RAM),
+			Accelerator: accelerator,
+			HourlyPrice: types.Float64Value(hourlyPrice(flavor)),
+		})
+	}
+
+	namesValue, diags := stringListValue(ctx, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = namesValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hourlyPrice converts the price of a flavor to a price per hour.
+func hourlyPrice(flavor hfclient.HardwareFlavor) float64 {
+	switch flavor.UnitLabel {
+	case "minute":
+		return flavor.UnitCostUSD * 60
+	case "second":
+		return flavor.UnitCostUSD * 3600
+	default:
+		return flavor.UnitCostUSD
+	}
+}
+
+func NewHardwareFlavorsDataSource() datasource.DataSource {
+	return &HardwareFlavorsDataSource{}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import "testing"
+
+func TestNormalizeHardware(t *testing.T) {
+	tests := map[string]struct {
+		hardware string
+		expected string
+		known    bool
+	}{
+		"canonical":   {hardware: "a10g-small", expected: "a10g-small", known: true},
+		"upper case":  {hardware: "A10G-Small", expected: "a10g-small", known: true},
+		"spaces":      {hardware: " A10G Small ", expected: "a10g-small", known: true},
+		"underscores": {hardware: "t4_medium", expected: "t4-medium", known: true},
+		"alias":       {hardware: "A10G", expected: "a10g-small", known: true},
+		"zero alias":  {hardware: "ZeroGPU", expected: "zero-a10g", known: true},
+		"unknown":     {hardware: "Quantum XL", expected: "quantum-xl", known: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, known := normalizeHardware(test.hardware)
+			if actual != test.expected || known != test.known {
+				t.Errorf("normalizeHardware(%q) = %q, %t, expected %q, %t", test.hardware, actual, known, test.expected, test.known)
+			}
+		})
+	}
+}
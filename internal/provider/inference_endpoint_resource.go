@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &InferenceEndpointResource{}
+	_ resource.ResourceWithConfigure   = &InferenceEndpointResource{}
+	_ resource.ResourceWithImportState = &InferenceEndpointResource{}
+)
+
+const (
+	// defaultEndpointCreateTimeout bounds Create, including the wait for the
+	// endpoint to be ready, unless timeouts.create is set.
+	defaultEndpointCreateTimeout = 30 * time.Minute
+
+	// defaultEndpointUpdateTimeout bounds Update, including the wait for the
+	// endpoint to be ready, unless timeouts.update is set.
+	defaultEndpointUpdateTimeout = 30 * time.Minute
+
+	// defaultEndpointDeleteTimeout bounds Delete unless timeouts.delete is
+	// set.
+	defaultEndpointDeleteTimeout = 10 * time.Minute
+
+	// endpointPollInterval is the time between two checks of the state of
+	// an endpoint.
+	endpointPollInterval = 10 * time.Second
+)
+
+// endpointTypes lists who can access an endpoint: anyone, anyone with a
+// token, or only from a private network.
+var endpointTypes = []string{"public", "protected", "private"}
+
+// endpointAccelerators lists the accelerators an endpoint can run on.
+var endpointAccelerators = []string{"cpu", "gpu", "neuron"}
+
+// readyEndpointStates lists the states of an endpoint that is ready to serve
+// requests, or to scale up when it receives one.
+var readyEndpointStates = map[string]bool{
+	"running":      true,
+	"scaledToZero": true,
+}
+
+// failedEndpointStates lists the states an endpoint does not recover from by
+// itself.
+var failedEndpointStates = map[string]bool{
+	"failed":       true,
+	"updateFailed": true,
+}
+
+// InferenceEndpointResource defines the resource implementation.
+type InferenceEndpointResource struct {
+	client *hfclient.Client
+}
+
+// InferenceEndpointResourceModel describes the resource data model.
+type InferenceEndpointResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Namespace          types.String   `tfsdk:"namespace"`
+	Type               types.String   `tfsdk:"type"`
+	Repository         types.String   `tfsdk:"repository"`
+	Revision           types.String   `tfsdk:"revision"`
+	Framework          types.String   `tfsdk:"framework"`
+	Task               types.String   `tfsdk:"task"`
+	Vendor             types.String   `tfsdk:"vendor"`
+	Region             types.String   `tfsdk:"region"`
+	Accelerator        types.String   `tfsdk:"accelerator"`
+	InstanceType       types.String   `tfsdk:"instance_type"`
+	InstanceSize       types.String   `tfsdk:"instance_size"`
+	MinReplica         types.Int64    `tfsdk:"min_replica"`
+	MaxReplica         types.Int64    `tfsdk:"max_replica"`
+	ScaleToZeroTimeout types.Int64    `tfsdk:"scale_to_zero_timeout"`
+	WaitForReady       types.Bool     `tfsdk:"wait_for_ready"`
+	URL                types.String   `tfsdk:"url"`
+	Status             types.String   `tfsdk:"status"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *InferenceEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inference_endpoint"
+}
+
+func (r *InferenceEndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a dedicated Inference Endpoint serving a model from the Hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the endpoint, in the form `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the endpoint. Changing this forces a new endpoint to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The user or organization the endpoint belongs to. Defaults to the owner of the token. Changing this forces a new endpoint to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Who can access the endpoint, one of `public`, `protected` or `private`. Defaults to `protected`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("protected"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(endpointTypes...),
+				},
+			},
+			"repository": schema.StringAttribute{
+				MarkdownDescription: "The ID of the model repository to serve.",
+				Required:            true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The revision of the model to serve. Defaults to the latest commit of the main branch at creation.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"framework": schema.StringAttribute{
+				MarkdownDescription: "The framework of the model, such as `pytorch`. Defaults to the framework detected by the API.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"task": schema.StringAttribute{
+				MarkdownDescription: "The task of the model, such as `text-generation`. Defaults to the task of the model repository.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vendor": schema.StringAttribute{
+				MarkdownDescription: "The cloud vendor to run the endpoint on, such as `aws`. Changing this forces a new endpoint to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The region of the vendor to run the endpoint in, such as `us-east-1`. Changing this forces a new endpoint to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"accelerator": schema.StringAttribute{
+				MarkdownDescription: "The accelerator of the instances, one of `cpu`, `gpu` or `neuron`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(endpointAccelerators...),
+				},
+			},
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the instances, such as `nvidia-a10g`.",
+				Required:            true,
+			},
+			"instance_size": schema.StringAttribute{
+				MarkdownDescription: "The size of the instances, such as `x1`.",
+				Required:            true,
+			},
+			"min_replica": schema.Int64Attribute{
+				MarkdownDescription: "The minimum number of replicas. `0` lets the endpoint scale to zero. Defaults to `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_replica": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of replicas. Defaults to `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtLeastSumOf(path.MatchRoot("min_replica")),
+				},
+			},
+			"scale_to_zero_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Minutes without requests after which an endpoint with `min_replica` set to `0` scales to zero.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Whether create and update wait until the endpoint is running or scaled to zero, failing if it fails to start instead. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL to send inference requests to, once the endpoint has started.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The state of the endpoint, such as `initializing`, `running` or `scaledToZero`.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *InferenceEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *InferenceEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *InferenceEndpointResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultEndpointCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Unlike repositories, endpoints are always addressed by their
+	// namespace, so it is resolved from the token when not configured.
+	if data.Namespace.IsUnknown() {
+		whoami, err := r.client.WhoAmI(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read authenticated user", err)
+			return
+		}
+		data.Namespace = types.StringValue(whoami.Name)
+	}
+
+	in := hfclient.InferenceEndpoint{
+		Name: data.Name.ValueString(),
+		Type: data.Type.ValueString(),
+		Provider: hfclient.InferenceEndpointProvider{
+			Vendor: data.Vendor.ValueString(),
+			Region: data.Region.ValueString(),
+		},
+		Compute: endpointCompute(data),
+		Model:   endpointModel(data),
+	}
+
+	endpoint, err := r.client.CreateInferenceEndpoint(ctx, data.Namespace.ValueString(), in)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create inference endpoint", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.Namespace.ValueString() + "/" + data.Name.ValueString())
+	setEndpointModel(data, endpoint)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The endpoint is saved to state before waiting for it, so that an
+	// endpoint which fails to start is tainted rather than forgotten.
+	if data.WaitForReady.ValueBool() {
+		r.waitForEndpoint(ctx, data, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+}
+
+func (r *InferenceEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *InferenceEndpointResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := r.client.GetInferenceEndpoint(ctx, data.Namespace.ValueString(), data.Name.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Inference endpoint %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read inference endpoint", err)
+		return
+	}
+
+	data.Vendor = types.StringValue(endpoint.Provider.Vendor)
+	data.Region = types.StringValue(endpoint.Provider.Region)
+	setEndpointModel(data, endpoint)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InferenceEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *InferenceEndpointResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultEndpointUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	compute := endpointCompute(data)
+	model := endpointModel(data)
+
+	endpoint, err := r.client.UpdateInferenceEndpoint(ctx, data.Namespace.ValueString(), data.Name.ValueString(), hfclient.UpdateInferenceEndpointRequest{
+		Type:    data.Type.ValueString(),
+		Compute: &compute,
+		Model:   &model,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update inference endpoint", err)
+		return
+	}
+
+	setEndpointModel(data, endpoint)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.WaitForReady.ValueBool() {
+		r.waitForEndpoint(ctx, data, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+}
+
+func (r *InferenceEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *InferenceEndpointResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultEndpointDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteInferenceEndpoint(ctx, data.Namespace.ValueString(), data.Name.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete inference endpoint", err)
+		return
+	}
+}
+
+func (r *InferenceEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, name := splitRepoID(req.ID)
+	if namespace == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_ready"), true)...)
+}
+
+// waitForEndpoint polls an endpoint until it is ready, it failed, or the
+// context is done, refreshing data with the last state seen.
+func (r *InferenceEndpointResource) waitForEndpoint(ctx context.Context, data *InferenceEndpointResourceModel, diags *diag.Diagnostics) {
+	for {
+		endpoint, err := r.client.GetInferenceEndpoint(ctx, data.Namespace.ValueString(), data.Name.ValueString())
+		if err != nil {
+			addClientError(diags, "read inference endpoint", err)
+			return
+		}
+
+		setEndpointModel(data, endpoint)
+		state := data.Status.ValueString()
+
+		tflog.Debug(ctx, fmt.Sprintf("Inference endpoint %s is %s", data.ID.ValueString(), state))
+
+		if readyEndpointStates[state] {
+			return
+		}
+
+		if failedEndpointStates[state] {
+			reason := "no reason given"
+			if endpoint.Status != nil && endpoint.Status.Message != nil {
+				reason = *endpoint.Status.Message
+			}
+			diags.AddError("Inference Endpoint Not Ready", fmt.Sprintf("Inference endpoint %s is %s: %s", data.ID.ValueString(), state, reason))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Inference Endpoint Not Ready", fmt.Sprintf("Inference endpoint %s did not become ready, got error: %s, last state: %s", data.ID.ValueString(), ctx.Err(), state))
+			return
+		case <-time.After(endpointPollInterval):
+		}
+	}
+}
+
+// endpointCompute returns the compute configuration of an endpoint as planned
+// in data.
+func endpointCompute(data *InferenceEndpointResourceModel) hfclient.InferenceEndpointCompute {
+	compute := hfclient.InferenceEndpointCompute{
+		Accelerator:  data.Accelerator.ValueString(),
+		InstanceType: data.InstanceType.ValueString(),
+		InstanceSize: data.InstanceSize.ValueString(),
+		Scaling: hfclient.InferenceEndpointScaling{
+			MinReplica: data.MinReplica.ValueInt64(),
+			MaxReplica: data.MaxReplica.ValueInt64(),
+		},
+	}
+	if !data.ScaleToZeroTimeout.IsUnknown() {
+		compute.Scaling.ScaleToZeroTimeout = data.ScaleToZeroTimeout.ValueInt64Pointer()
+	}
+
+	return compute
+}
+
+// endpointModel returns the model configuration of an endpoint as planned in
+// data. Attributes left to the API are omitted.
+func endpointModel(data *InferenceEndpointResourceModel) hfclient.InferenceEndpointModel {
+	model := hfclient.InferenceEndpointModel{
+		Repository: data.Repository.ValueString(),
+		Image: map[string]interface{}{
+			"huggingface": map[string]interface{}{},
+		},
+	}
+	if !data.Revision.IsUnknown() {
+		model.Revision = data.Revision.ValueString()
+	}
+	if !data.Framework.IsUnknown() {
+		model.Framework = data.Framework.ValueString()
+	}
+	if !data.Task.IsUnknown() {
+		model.Task = data.Task.ValueString()
+	}
+
+	return model
+}
+
+// setEndpointModel refreshes data with an endpoint as returned by the API.
+func setEndpointModel(data *InferenceEndpointResourceModel, endpoint *hfclient.InferenceEndpoint) {
+	data.Type = types.StringValue(endpoint.Type)
+	data.Repository = types.StringValue(endpoint.Model.Repository)
+	data.Revision = types.StringValue(endpoint.Model.Revision)
+	data.Framework = types.StringValue(endpoint.Model.Framework)
+	data.Task = types.StringValue(endpoint.Model.Task)
+	data.Accelerator = types.StringValue(endpoint.Compute.Accelerator)
+	data.InstanceType = types.StringValue(endpoint.Compute.InstanceType)
+	data.InstanceSize = types.StringValue(endpoint.Compute.InstanceSize)
+	data.MinReplica = types.Int64Value(endpoint.Compute.Scaling.MinReplica)
+	data.MaxReplica = types.Int64Value(endpoint.Compute.Scaling.MaxReplica)
+	data.ScaleToZeroTimeout = types.Int64PointerValue(endpoint.Compute.Scaling.ScaleToZeroTimeout)
+
+	data.URL = types.StringNull()
+	data.Status = types.StringNull()
+	if endpoint.Status != nil {
+		data.URL = types.StringPointerValue(endpoint.Status.URL)
+		data.Status = types.StringValue(endpoint.Status.State)
+	}
+}
+
+func NewInferenceEndpointResource() resource.Resource {
+	return &InferenceEndpointResource{}
+}
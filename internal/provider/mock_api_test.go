@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// mockAPI is a test server standing in for the Hub API. It answers requests
+// with the handlers registered for their method and path, answering 404 for
+// any other request, and records every request it receives.
+type mockAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []mockRequest
+}
+
+// mockRequest is a request received by a mockAPI.
+type mockRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// newMockAPI starts a mockAPI that is closed when the test ends.
+func newMockAPI(t *testing.T) *mockAPI {
+	t.Helper()
+
+	api := &mockAPI{
+		handlers: make(map[string]http.HandlerFunc),
+	}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(api.Close)
+
+	return api
+}
+
+func (a *mockAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	a.mu.Lock()
+	a.requests = append(a.requests, mockRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   string(body),
+	})
+	handler, ok := a.handlers[r.Method+" "+r.URL.Path]
+	a.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error": "Not Found"}`)
+		return
+	}
+
+	handler(w, r)
+}
+
+// handleFunc registers handler for the requests of method to path, such as
+// /api/spaces/testuser/example/runtime.
+func (a *mockAPI) handleFunc(method string, path string, handler http.HandlerFunc) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.handlers[method+" "+path] = handler
+}
+
+// handle answers the requests of method to path with status and the JSON
+// body.
+func (a *mockAPI) handle(method string, path string, status int, body string) {
+	a.handleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	})
+}
+
+// requestsTo returns the requests received of method to path, or to any
+// path if path is empty, in the order they were received.
+func (a *mockAPI) requestsTo(method string, path string) []mockRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var requests []mockRequest
+	for _, request := range a.requests {
+		if request.Method == method && (path == "" || request.Path == path) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
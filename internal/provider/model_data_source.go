@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &ModelDataSource{}
+
+// ModelDataSource defines the data source implementation.
+type ModelDataSource struct {
+	client *hfclient.Client
+}
+
+// ModelDataSourceModel describes the data source data model.
+type ModelDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Author       types.String `tfsdk:"author"`
+	Private      types.Bool   `tfsdk:"private"`
+	Gated        types.String `tfsdk:"gated"`
+	Disabled     types.Bool   `tfsdk:"disabled"`
+	SHA          types.String `tfsdk:"sha"`
+	LastModified types.String `tfsdk:"last_modified"`
+	Downloads    types.Int64  `tfsdk:"downloads"`
+	Likes        types.Int64  `tfsdk:"likes"`
+	PipelineTag  types.String `tfsdk:"pipeline_tag"`
+	Library      types.String `tfsdk:"library"`
+	Tags         types.List   `tfsdk:"tags"`
+}
+
+func (d *ModelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (d *ModelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing model on the Hugging Face Hub without managing it. Reading a model that does not exist, or that the token cannot access, fails.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the model, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"author": schema.StringAttribute{
+				MarkdownDescription: "The user or organization owning the model.",
+				Computed:            true,
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the model is private.",
+				Computed:            true,
+			},
+			"gated": schema.StringAttribute{
+				MarkdownDescription: "How access to the model is gated, one of `disabled`, `auto` or `manual`.",
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the model was disabled by the Hub.",
+				Computed:            true,
+			},
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the latest commit of the model.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "When the model was last modified.",
+				Computed:            true,
+			},
+			"downloads": schema.Int64Attribute{
+				MarkdownDescription: "The number of downloads of the model over the last 30 days.",
+				Computed:            true,
+			},
+			"likes": schema.Int64Attribute{
+				MarkdownDescription: "The number of likes of the model.",
+				Computed:            true,
+			},
+			"pipeline_tag": schema.StringAttribute{
+				MarkdownDescription: "The task of the model, such as `text-generation`.",
+				Computed:            true,
+			},
+			"library": schema.StringAttribute{
+				MarkdownDescription: "The library of the model, such as `transformers`.",
+				Computed:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Tags of the model.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ModelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModelDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, err := d.client.GetModel(ctx, data.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read model %s", data.ID.ValueString()), err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read model %s", data.ID.ValueString()))
+
+	data.Author = types.StringPointerValue(model.Author)
+	data.Private = types.BoolPointerValue(model.Private)
+	data.Gated = types.StringValue(gatedMode(model.Gated))
+	data.Disabled = types.BoolValue(model.Disabled != nil && *model.Disabled)
+	data.SHA = types.StringPointerValue(model.SHA)
+	data.LastModified = types.StringPointerValue(model.LastModified)
+	data.Downloads = types.Int64PointerValue(model.Downloads)
+	data.Likes = types.Int64PointerValue(model.Likes)
+	data.PipelineTag = types.StringPointerValue(model.PipelineTag)
+	data.Library = types.StringPointerValue(model.LibraryName)
+
+	tags, diags := stringListValue(ctx, model.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tags
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewModelDataSource() datasource.DataSource {
+	return &ModelDataSource{}
+}
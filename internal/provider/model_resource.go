@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ModelResource{}
+	_ resource.ResourceWithConfigure   = &ModelResource{}
+	_ resource.ResourceWithImportState = &ModelResource{}
+	_ resource.ResourceWithModifyPlan  = &ModelResource{}
+)
+
+// ModelResource defines the resource implementation.
+type ModelResource struct {
+	client *hfclient.Client
+}
+
+// ModelResourceModel describes the resource data model.
+type ModelResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Namespace    types.String `tfsdk:"namespace"`
+	Private      types.Bool   `tfsdk:"private"`
+	License      types.String `tfsdk:"license"`
+	Gated        types.String `tfsdk:"gated"`
+	Gating       types.Object `tfsdk:"gating"`
+	SHA          types.String `tfsdk:"sha"`
+	LastModified types.String `tfsdk:"last_modified"`
+}
+
+func (r *ModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (r *ModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a model repository on the Hugging Face Hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the model, in the form `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the model. Changing this renames the model, which keeps its contents and settings.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The user or organization the model belongs to. Defaults to the owner of the token. Changing this forces a new model to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					// Imported models have the namespace of their ID in
+					// state, which an unconfigured namespace must keep.
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the model is private.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"license": schema.StringAttribute{
+				MarkdownDescription: "The license of the model, such as `apache-2.0`, set in the metadata of its card.",
+				Optional:            true,
+			},
+			"gated":  gatedAttribute("model"),
+			"gating": gatingAttribute("model"),
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the latest commit on the main branch.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "When the model was last modified.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRepoRename(ctx, hfclient.RepoTypeModel, req, resp)
+}
+
+func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ModelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := hfclient.CreateRepoRequest{
+		Type:         hfclient.RepoTypeModel,
+		Name:         data.Name.ValueString(),
+		Organization: data.Namespace.ValueString(),
+		License:      data.License.ValueString(),
+	}
+	if !data.Private.IsUnknown() {
+		createReq.Private = data.Private.ValueBoolPointer()
+	}
+
+	created, err := r.client.CreateRepo(ctx, createReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create model", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create Model Response: %+v", *created))
+
+	modelName := created.Name
+	if modelName == "" {
+		resp.Diagnostics.AddError("Invalid Response", "Unable to extract model name from create model response")
+		return
+	}
+
+	data.ID = types.StringValue(modelName)
+
+	// Gating can only be configured once the model exists
+	if !data.Gated.IsUnknown() && data.Gated.ValueString() != gatedDisabled {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	writeRepoGating(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), data.Gating, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The license is sent with the create request, and written to the card
+	// in case the Hub did not add it there.
+	writeRepoLicense(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), data.License, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readModel(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Model %s was not found after it was created", data.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ModelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readModel(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("Model %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *ModelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the model needs to be renamed
+	if toRepo := repoRenameTarget(state.ID.ValueString(), data.Name.ValueString()); toRepo != state.ID.ValueString() {
+		renameRepo(ctx, r.client, hfclient.RepoTypeModel, state.ID.ValueString(), toRepo, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.ID = types.StringValue(toRepo)
+		state.Name = data.Name
+	}
+
+	// Check if the model visibility needs to be updated
+	if !data.Private.IsUnknown() && !data.Private.Equal(state.Private) {
+		setRepoPrivate(ctx, r.client, hfclient.RepoTypeModel, state.ID.ValueString(), data.Private.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Private = data.Private
+	}
+
+	// Check if the model license needs to be updated
+	if !data.License.Equal(state.License) {
+		writeRepoLicense(ctx, r.client, hfclient.RepoTypeModel, state.ID.ValueString(), data.License, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.License = data.License
+	}
+
+	// Check if the model gating needs to be updated
+	if !data.Gated.IsUnknown() && state.Gated.ValueString() != data.Gated.ValueString() {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeModel, state.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Gated = data.Gated
+	}
+
+	// Check if the gating form needs to be updated
+	if !data.Gating.IsUnknown() && !data.Gating.Equal(state.Gating) {
+		writeRepoGating(ctx, r.client, hfclient.RepoTypeModel, state.ID.ValueString(), data.Gating, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Gating = data.Gating
+	}
+
+	found := r.readModel(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Model %s was not found after it was updated", state.ID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ModelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteRepo(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), &resp.Diagnostics)
+}
+
+func (r *ModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRepoState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importRepoLicense(ctx, r.client, hfclient.RepoTypeModel, req.ID, resp)
+}
+
+// readModel refreshes data with the model as returned by the API. It reports
+// whether the model exists.
+func (r *ModelResource) readModel(ctx context.Context, data *ModelResourceModel, diags *diag.Diagnostics) bool {
+	responseData, err := r.client.GetModel(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		addClientError(diags, "read model", err)
+		return false
+	}
+
+	namespace, _ := splitRepoID(data.ID.ValueString())
+	data.Namespace = types.StringValue(namespace)
+	if responseData.Private != nil {
+		data.Private = types.BoolValue(*responseData.Private)
+	}
+	data.Gated = types.StringValue(gatedMode(responseData.Gated))
+	data.Gating = readRepoGating(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), data.Gating, diags)
+	data.License = readRepoLicense(ctx, r.client, hfclient.RepoTypeModel, data.ID.ValueString(), data.License, diags)
+	data.SHA = types.StringPointerValue(responseData.SHA)
+	data.LastModified = types.StringPointerValue(responseData.LastModified)
+
+	return true
+}
+
+func NewModelResource() resource.Resource {
+	return &ModelResource{}
+}
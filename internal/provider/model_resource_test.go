@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+// testModelType is the type name of the model resource.
+const testModelType = "huggingface-spaces_model"
+
+func TestModelResourceLifecycle(t *testing.T) {
+	hub := testutil.NewHub(t)
+	model := newHubProvider(t, hub).resource(testModelType)
+
+	config := map[string]attr.Value{
+		"name":    types.StringValue("classifier"),
+		"private": types.BoolValue(true),
+		"license": types.StringValue("apache-2.0"),
+	}
+	requireNoErrors(t, "create", model.apply(config))
+
+	created := hub.Repo(hfclient.RepoTypeModel, "testutil/classifier")
+	if created == nil {
+		t.Fatal("model was not created")
+	}
+	if !created.Private || !strings.Contains(string(created.Files["README.md"]), "license: apache-2.0") {
+		t.Errorf("model was created as %+v with card %q", created, created.Files["README.md"])
+	}
+	if !model.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	imported := newHubProvider(t, hub).resource(testModelType)
+	requireNoErrors(t, "import", imported.importState("testutil/classifier"))
+	for _, name := range []string{"id", "name", "namespace", "private", "license", "sha"} {
+		if !imported.attribute(name).Equal(model.attribute(name)) {
+			t.Errorf("imported %s = %s, expected %s", name, imported.attribute(name), model.attribute(name))
+		}
+	}
+	if !imported.planIsEmpty(config) {
+		t.Error("plan is not empty after import")
+	}
+
+	requireNoErrors(t, "destroy", model.destroy())
+	if hub.Repo(hfclient.RepoTypeModel, "testutil/classifier") != nil {
+		t.Error("model was not deleted")
+	}
+}
+
+func TestModelResourceUpdateInPlace(t *testing.T) {
+	hub := testutil.NewHub(t)
+	model := newHubProvider(t, hub).resource(testModelType)
+
+	config := map[string]attr.Value{
+		"name":    types.StringValue("classifier"),
+		"license": types.StringValue("apache-2.0"),
+	}
+	requireNoErrors(t, "create", model.apply(config))
+
+	// Changing the name and license keeps the model.
+	config["name"] = types.StringValue("sentiment")
+	config["license"] = types.StringValue("mit")
+	_, requiresReplace, diags := model.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Fatalf("changing the name and license requires replacing %v", requiresReplace)
+	}
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, "Repository Will Be Renamed") == nil {
+		t.Errorf("plan did not warn about the rename, got %v", diags)
+	}
+	requireNoErrors(t, "update", model.apply(config))
+
+	if hub.Repo(hfclient.RepoTypeModel, "testutil/classifier") != nil {
+		t.Error("model was not renamed")
+	}
+	renamed := hub.Repo(hfclient.RepoTypeModel, "testutil/sentiment")
+	if renamed == nil {
+		t.Fatal("model was not renamed to sentiment")
+	}
+	if card := string(renamed.Files["README.md"]); !strings.Contains(card, "license: mit") {
+		t.Errorf("card of the model is %q, expected license mit", card)
+	}
+	if requests := hub.Requests(http.MethodDelete, "/api/repos/delete"); len(requests) != 0 {
+		t.Errorf("sent %d delete requests, expected the model to be updated in place", len(requests))
+	}
+	if id := model.stringAttribute("id"); id != "testutil/sentiment" {
+		t.Errorf("id = %q, expected testutil/sentiment", id)
+	}
+	if !model.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+
+	// A license changed outside of Terraform is set back.
+	changed := hub.Repo(hfclient.RepoTypeModel, "testutil/sentiment")
+	changed.Files["README.md"] = []byte("---\nlicense: other\n---\n# Sentiment\n")
+	hub.PutRepo(*changed)
+
+	if model.planIsEmpty(config) {
+		t.Fatal("license changed outside of Terraform was not detected")
+	}
+	requireNoErrors(t, "update", model.apply(config))
+	if card := string(hub.Repo(hfclient.RepoTypeModel, "testutil/sentiment").Files["README.md"]); card != "---\nlicense: mit\n---\n# Sentiment\n" {
+		t.Errorf("card of the model is %q, expected license mit and its body kept", card)
+	}
+}
+
+func TestModelResourceRenameTaken(t *testing.T) {
+	hub := testutil.NewHub(t)
+	hub.PutRepo(testutil.Repo{Type: hfclient.RepoTypeModel, ID: "testutil/taken"})
+	model := newHubProvider(t, hub).resource(testModelType)
+
+	config := map[string]attr.Value{
+		"name": types.StringValue("classifier"),
+	}
+	requireNoErrors(t, "create", model.apply(config))
+
+	config["name"] = types.StringValue("taken")
+	diags := model.apply(config)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Repository Rename Rejected") == nil {
+		t.Errorf("renaming to a taken name did not fail, got %v", diags)
+	}
+	if hub.Repo(hfclient.RepoTypeModel, "testutil/classifier") == nil {
+		t.Error("model was moved despite the failed rename")
+	}
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &ModelsDataSource{}
+
+// modelsSortFields maps the sort orders of the models data source to the
+// fields the API sorts by.
+var modelsSortFields = map[string]string{
+	"downloads": "downloads",
+	"likes":     "likes",
+	"modified":  "lastModified",
+}
+
+// ModelsDataSource defines the data source implementation.
+type ModelsDataSource struct {
+	client *hfclient.Client
+}
+
+// ModelsDataSourceModel describes the data source data model.
+type ModelsDataSourceModel struct {
+	Author      types.String       `tfsdk:"author"`
+	Search      types.String       `tfsdk:"search"`
+	PipelineTag types.String       `tfsdk:"pipeline_tag"`
+	Library     types.String       `tfsdk:"library"`
+	Sort        types.String       `tfsdk:"sort"`
+	Limit       types.Int64        `tfsdk:"limit"`
+	IDs         types.List         `tfsdk:"ids"`
+	Models      []ModelsEntryModel `tfsdk:"models"`
+}
+
+// ModelsEntryModel describes a model listed by the data source.
+type ModelsEntryModel struct {
+	ID           types.String `tfsdk:"id"`
+	Author       types.String `tfsdk:"author"`
+	Private      types.Bool   `tfsdk:"private"`
+	Gated        types.String `tfsdk:"gated"`
+	Downloads    types.Int64  `tfsdk:"downloads"`
+	Likes        types.Int64  `tfsdk:"likes"`
+	PipelineTag  types.String `tfsdk:"pipeline_tag"`
+	Library      types.String `tfsdk:"library"`
+	LastModified types.String `tfsdk:"last_modified"`
+}
+
+func (d *ModelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_models"
+}
+
+func (d *ModelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the models on the Hugging Face Hub.",
+		Attributes: map[string]schema.Attribute{
+			"author": schema.StringAttribute{
+				MarkdownDescription: "Only list models owned by this user or organization.",
+				Optional:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "Only list models whose ID contains this term.",
+				Optional:            true,
+			},
+			"pipeline_tag": schema.StringAttribute{
+				MarkdownDescription: "Only list models for this task, such as `text-generation`.",
+				Optional:            true,
+			},
+			"library": schema.StringAttribute{
+				MarkdownDescription: "Only list models for this library, such as `transformers`.",
+				Optional:            true,
+			},
+			"sort": schema.StringAttribute{
+				MarkdownDescription: "List the most downloaded models first with `downloads`, the most liked ones with `likes`, or the most recently modified ones with `modified`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("downloads", "likes", "modified"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of models to list. Defaults to all of them.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the models, in the form `namespace/name`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"models": schema.ListNestedAttribute{
+				MarkdownDescription: "The models.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the model, in the form `namespace/name`.",
+							Computed:            true,
+						},
+						"author": schema.StringAttribute{
+							MarkdownDescription: "The user or organization owning the model.",
+							Computed:            true,
+						},
+						"private": schema.BoolAttribute{
+							MarkdownDescription: "Whether the model is private.",
+							Computed:            true,
+						},
+						"gated": schema.StringAttribute{
+							MarkdownDescription: "How access to the model is gated, one of `disabled`, `auto` or `manual`.",
+							Computed:            true,
+						},
+						"downloads": schema.Int64Attribute{
+							MarkdownDescription: "The number of downloads of the model over the last 30 days.",
+							Computed:            true,
+						},
+						"likes": schema.Int64Attribute{
+							MarkdownDescription: "The number of likes of the model.",
+							Computed:            true,
+						},
+						"pipeline_tag": schema.StringAttribute{
+							MarkdownDescription: "The task of the model.",
+							Computed:            true,
+						},
+						"library": schema.StringAttribute{
+							MarkdownDescription: "The library of the model.",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: "When the model was last modified.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ModelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModelsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	models, err := d.client.ListModels(ctx, hfclient.ListModelsRequest{
+		Author:      data.Author.ValueString(),
+		Search:      data.Search.ValueString(),
+		PipelineTag: data.PipelineTag.ValueString(),
+		Library:     data.Library.ValueString(),
+		Sort:        modelsSortFields[data.Sort.ValueString()],
+		Limit:       int(data.Limit.ValueInt64()),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list models", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d models", len(models)))
+
+	ids := make([]string, 0, len(models))
+	data.Models = make([]ModelsEntryModel, 0, len(models))
+	for _, model := range models {
+		if model.ID == nil {
+			continue
+		}
+
+		ids = append(ids, *model.ID)
+		data.Models = append(data.Models, ModelsEntryModel{
+			ID:           types.StringPointerValue(model.ID),
+			Author:       types.StringPointerValue(model.Author),
+			Private:      types.BoolPointerValue(model.Private),
+			Gated:        types.StringValue(gatedMode(model.Gated)),
+			Downloads:    types.Int64PointerValue(model.Downloads),
+			Likes:        types.Int64PointerValue(model.Likes),
+			PipelineTag:  types.StringPointerValue(model.PipelineTag),
+			Library:      types.StringPointerValue(model.LibraryName),
+			LastModified: types.StringPointerValue(model.LastModified),
+		})
+	}
+
+	idsValue, diags := stringListValue(ctx, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewModelsDataSource() datasource.DataSource {
+	return &ModelsDataSource{}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &OrgMemberResource{}
+	_ resource.ResourceWithConfigure   = &OrgMemberResource{}
+	_ resource.ResourceWithImportState = &OrgMemberResource{}
+)
+
+// orgRoles lists the roles of organization members.
+var orgRoles = []string{"admin", "write", "contributor", "read"}
+
+// OrgMemberResource defines the resource implementation.
+type OrgMemberResource struct {
+	client *hfclient.Client
+}
+
+// OrgMemberResourceModel describes the resource data model.
+type OrgMemberResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Organization types.String `tfsdk:"organization"`
+	Username     types.String `tfsdk:"username"`
+	Role         types.String `tfsdk:"role"`
+	Pending      types.Bool   `tfsdk:"pending"`
+}
+
+func (r *OrgMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_member"
+}
+
+func (r *OrgMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the membership of a user in an organization. Users are invited, and become members once they accept the invitation. Requires an admin token of the organization.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the membership, in the form `organization/username`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "The name of the organization. Changing this forces a new membership to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The name of the user. Changing this forces a new membership to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the user in the organization, one of `admin`, `write`, `contributor` or `read`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(orgRoles...),
+				},
+			},
+			"pending": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user has yet to accept the invitation to the organization.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OrgMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *OrgMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *OrgMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	org := data.Organization.ValueString()
+	username := data.Username.ValueString()
+	data.ID = types.StringValue(org + "/" + username)
+
+	// Users that already are members only need their role set.
+	member, err := r.findOrgMember(ctx, org, username)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list members of %s", org), err)
+		return
+	}
+
+	if member != nil {
+		tflog.Debug(ctx, fmt.Sprintf("User %s already is a member of %s, setting role %s", username, org, data.Role.ValueString()))
+
+		if err := r.client.SetOrgMemberRole(ctx, org, username, data.Role.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("set role of %s", username), err)
+			return
+		}
+		data.Pending = types.BoolValue(false)
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Inviting user %s to %s as %s", username, org, data.Role.ValueString()))
+
+		if err := r.client.InviteOrgMember(ctx, org, username, data.Role.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("invite %s", username), err)
+			return
+		}
+		data.Pending = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrgMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *OrgMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	org := data.Organization.ValueString()
+	username := data.Username.ValueString()
+
+	member, err := r.findOrgMember(ctx, org, username)
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Organization %s not found, removing membership from state", org))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list members of %s", org), err)
+		return
+	}
+
+	switch {
+	case member != nil:
+		data.Pending = types.BoolValue(false)
+		// The role is only returned to admins.
+		if member.Role != "" {
+			data.Role = types.StringValue(member.Role)
+		}
+	case data.Pending.ValueBool():
+		// Invitations are not listed, so a user that has yet to accept
+		// one is kept as is.
+		tflog.Debug(ctx, fmt.Sprintf("Invitation of %s to %s is still pending", username, org))
+	default:
+		tflog.Debug(ctx, fmt.Sprintf("User %s is no longer a member of %s, removing it from state", username, org))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrgMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *OrgMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	org := data.Organization.ValueString()
+	username := data.Username.ValueString()
+
+	// The role of a pending invitation is changed by inviting the user
+	// again.
+	if data.Pending.ValueBool() {
+		if err := r.client.InviteOrgMember(ctx, org, username, data.Role.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("invite %s", username), err)
+			return
+		}
+	} else {
+		if err := r.client.SetOrgMemberRole(ctx, org, username, data.Role.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("set role of %s", username), err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrgMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *OrgMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveOrgMember(ctx, data.Organization.ValueString(), data.Username.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("remove %s", data.Username.ValueString()), err)
+		return
+	}
+}
+
+func (r *OrgMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	org, username := splitRepoID(req.ID)
+	if org == "" || username == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form organization/username, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), org)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pending"), false)...)
+}
+
+// findOrgMember returns the member username of the organization org, or nil
+// if the user is not a member.
+func (r *OrgMemberResource) findOrgMember(ctx context.Context, org string, username string) (*hfclient.OrgMember, error) {
+	members, err := r.client.ListOrgMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.User == username {
+			return &member, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func NewOrgMemberResource() resource.Resource {
+	return &OrgMemberResource{}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &OrganizationDataSource{}
+
+// OrganizationDataSource defines the data source implementation.
+type OrganizationDataSource struct {
+	client *hfclient.Client
+}
+
+// OrganizationDataSourceModel describes the data source data model.
+type OrganizationDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	FullName    types.String `tfsdk:"fullname"`
+	Plan        types.String `tfsdk:"plan"`
+	Verified    types.Bool   `tfsdk:"verified"`
+	NumMembers  types.Int64  `tfsdk:"num_members"`
+	NumModels   types.Int64  `tfsdk:"num_models"`
+	NumDatasets types.Int64  `tfsdk:"num_datasets"`
+	NumSpaces   types.Int64  `tfsdk:"num_spaces"`
+}
+
+func (d *OrganizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *OrganizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up the public overview of an organization on the Hugging Face Hub. Reading an organization that does not exist fails.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the organization, which is the namespace of the repositories it owns.",
+				Required:            true,
+			},
+			"fullname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the organization.",
+				Computed:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "The plan of the organization, such as `team` or `enterprise`, or `free`.",
+				Computed:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is verified by Hugging Face.",
+				Computed:            true,
+			},
+			"num_members": schema.Int64Attribute{
+				MarkdownDescription: "The number of members of the organization.",
+				Computed:            true,
+			},
+			"num_models": schema.Int64Attribute{
+				MarkdownDescription: "The number of public models of the organization.",
+				Computed:            true,
+			},
+			"num_datasets": schema.Int64Attribute{
+				MarkdownDescription: "The number of public datasets of the organization.",
+				Computed:            true,
+			},
+			"num_spaces": schema.Int64Attribute{
+				MarkdownDescription: "The number of public spaces of the organization.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *OrganizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	org, err := d.client.GetOrganization(ctx, data.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read organization %s", data.Name.ValueString()), err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read organization %s", data.Name.ValueString()))
+
+	data.FullName = types.StringValue(org.FullName)
+	data.Plan = types.StringValue("free")
+	if org.Plan != nil {
+		data.Plan = types.StringValue(*org.Plan)
+	}
+	data.Verified = types.BoolValue(org.IsVerified)
+	data.NumMembers = types.Int64Value(org.NumUsers)
+	data.NumModels = types.Int64Value(org.NumModels)
+	data.NumDatasets = types.Int64Value(org.NumDatasets)
+	data.NumSpaces = types.Int64Value(org.NumSpaces)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return &OrganizationDataSource{}
+}
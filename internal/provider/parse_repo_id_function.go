@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &ParseRepoIDFunction{}
+
+// ParseRepoIDFunction defines the function implementation.
+type ParseRepoIDFunction struct{}
+
+// parseRepoIDAttrTypes are the attribute types of the object returned by
+// parse_repo_id.
+var parseRepoIDAttrTypes = map[string]attr.Type{
+	"namespace": types.StringType,
+	"name":      types.StringType,
+}
+
+func (f *ParseRepoIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_repo_id"
+}
+
+func (f *ParseRepoIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Splits a repository ID into its namespace and name.",
+		MarkdownDescription: "Splits the ID of a space, model or dataset of the form `namespace/name` into an object with `namespace` and `name` attributes, failing on IDs of any other form.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "repo_id",
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseRepoIDAttrTypes,
+		},
+	}
+}
+
+func (f *ParseRepoIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var repoID string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &repoID))
+	if resp.Error != nil {
+		return
+	}
+
+	if !repoIDRegexp.MatchString(repoID) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Expected a repository ID of the form namespace/name, got: %q", repoID))
+		return
+	}
+
+	namespace, name, _ := strings.Cut(repoID, "/")
+	result, diags := types.ObjectValue(parseRepoIDAttrTypes, map[string]attr.Value{
+		"namespace": types.StringValue(namespace),
+		"name":      types.StringValue(name),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+func NewParseRepoIDFunction() function.Function {
+	return &ParseRepoIDFunction{}
+}
@@ -0,0 +1,580 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+// testProvider drives the provider over the plugin protocol the way
+// Terraform does, so that resources can be planned and applied against a
+// mock of the Hub API or the fake Hub by go test alone, without a Terraform
+// binary. Acceptance tests run the same configurations through Terraform when
+// TF_ACC is set.
+type testProvider struct {
+	t       *testing.T
+	ctx     context.Context
+	server  tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// testResource is an instance of a resource managed through a testProvider,
+// holding its state and private state between operations.
+type testResource struct {
+	p        *testProvider
+	typeName string
+	schema   *tfprotov6.Schema
+
+	state   tftypes.Value
+	private []byte
+}
+
+// newTestProvider configures the provider with the attributes of config, with
+// retries disabled unless config sets max_retries so that failures surface
+// right away.
+func newTestProvider(t *testing.T, config map[string]attr.Value) *testProvider {
+	t.Helper()
+
+	p := &testProvider{
+		t:      t,
+		ctx:    context.Background(),
+		server: providerserver.NewProtocol6(New("test")())(),
+	}
+
+	schemas, err := p.server.GetProviderSchema(p.ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("getting provider schema: %s", err)
+	}
+	requireNoErrors(t, "get provider schema", schemas.Diagnostics)
+	p.schemas = schemas
+
+	providerConfig := map[string]attr.Value{
+		"max_retries": types.Int64Value(0),
+	}
+	for name, value := range config {
+		providerConfig[name] = value
+	}
+
+	resp, err := p.server.ConfigureProvider(p.ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.11.0",
+		Config:           p.dynamicValue(schemas.Provider, objectValue(t, schemas.Provider, providerConfig)),
+	})
+	if err != nil {
+		t.Fatalf("configuring provider: %s", err)
+	}
+	requireNoErrors(t, "configure provider", resp.Diagnostics)
+
+	return p
+}
+
+// newMockProvider configures the provider with the attributes of config, if
+// any, against api.
+func newMockProvider(t *testing.T, api *mockAPI, config ...map[string]attr.Value) *testProvider {
+	t.Helper()
+
+	return newTestProvider(t, mergeConfig(map[string]attr.Value{
+		"endpoint": types.StringValue(api.URL),
+	}, config))
+}
+
+// newHubProvider configures the provider with the attributes of config, if
+// any, against hub.
+func newHubProvider(t *testing.T, hub *testutil.Hub, config ...map[string]attr.Value) *testProvider {
+	t.Helper()
+
+	return newTestProvider(t, mergeConfig(map[string]attr.Value{
+		"endpoint": types.StringValue(hub.URL()),
+		"token":    types.StringValue(testutil.Token),
+	}, config))
+}
+
+// mergeConfig returns base with the attributes of each of configs set over
+// it in turn.
+func mergeConfig(base map[string]attr.Value, configs []map[string]attr.Value) map[string]attr.Value {
+	for _, config := range configs {
+		for name, value := range config {
+			base[name] = value
+		}
+	}
+	return base
+}
+
+// newCassetteProvider configures the provider against the cassette name,
+// see testAccCassette.
+func newCassetteProvider(t *testing.T, name string) *testProvider {
+	t.Helper()
+
+	return newTestProvider(t, map[string]attr.Value{
+		"endpoint": types.StringValue(testAccCassette(t, name).URL()),
+	})
+}
+
+// resource returns a resource of type typeName, such as
+// huggingface-spaces_space, that does not exist yet.
+func (p *testProvider) resource(typeName string) *testResource {
+	p.t.Helper()
+
+	schema, ok := p.schemas.ResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("resource %s is not defined", typeName)
+	}
+
+	return &testResource{
+		p:        p,
+		typeName: typeName,
+		schema:   schema,
+		state:    tftypes.NewValue(schema.ValueType(), nil),
+	}
+}
+
+// readDataSource reads the data source typeName with config, returning its
+// state and diagnostics.
+func (p *testProvider) readDataSource(typeName string, config map[string]attr.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+
+	schema, ok := p.schemas.DataSourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("data source %s is not defined", typeName)
+	}
+
+	resp, err := p.server.ReadDataSource(p.ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(schema, objectValue(p.t, schema, config)),
+	})
+	if err != nil {
+		p.t.Fatalf("reading data source %s: %s", typeName, err)
+	}
+
+	return p.value(schema, resp.State), resp.Diagnostics
+}
+
+// dynamicValue encodes value for a request.
+func (p *testProvider) dynamicValue(schema *tfprotov6.Schema, value tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(schema.ValueType(), value)
+	if err != nil {
+		p.t.Fatalf("encoding value: %s", err)
+	}
+	return &dynamicValue
+}
+
+// value decodes a value from a response, treating a missing one as null.
+func (p *testProvider) value(schema *tfprotov6.Schema, dynamicValue *tfprotov6.DynamicValue) tftypes.Value {
+	p.t.Helper()
+
+	if dynamicValue == nil {
+		return tftypes.NewValue(schema.ValueType(), nil)
+	}
+
+	value, err := dynamicValue.Unmarshal(schema.ValueType())
+	if err != nil {
+		p.t.Fatalf("decoding value: %s", err)
+	}
+	return value
+}
+
+// plan plans the resource with config against its current state, without
+// applying it. It returns the planned state, the attributes that require
+// replacement and the diagnostics of validation and planning.
+func (r *testResource) plan(config map[string]attr.Value) (tftypes.Value, []*tftypes.AttributePath, []*tfprotov6.Diagnostic) {
+	r.p.t.Helper()
+
+	planned, _, requiresReplace, diags := r.planConfig(r.state, r.private, objectValue(r.p.t, r.schema, config))
+	return planned, requiresReplace, diags
+}
+
+// planConfig validates and plans configValue against prior.
+func (r *testResource) planConfig(prior tftypes.Value, priorPrivate []byte, configValue tftypes.Value) (tftypes.Value, []byte, []*tftypes.AttributePath, []*tfprotov6.Diagnostic) {
+	r.p.t.Helper()
+
+	validateResp, err := r.p.server.ValidateResourceConfig(r.p.ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.typeName,
+		Config:   r.p.dynamicValue(r.schema, configValue),
+		ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+			WriteOnlyAttributesAllowed: true,
+		},
+	})
+	if err != nil {
+		r.p.t.Fatalf("validating %s: %s", r.typeName, err)
+	}
+	diags := validateResp.Diagnostics
+	if hasErrors(diags) {
+		return prior, priorPrivate, nil, diags
+	}
+
+	planResp, err := r.p.server.PlanResourceChange(r.p.ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.p.dynamicValue(r.schema, prior),
+		ProposedNewState: r.p.dynamicValue(r.schema, proposedNewState(r.p.t, r.schema, prior, configValue)),
+		Config:           r.p.dynamicValue(r.schema, configValue),
+		PriorPrivate:     priorPrivate,
+	})
+	if err != nil {
+		r.p.t.Fatalf("planning %s: %s", r.typeName, err)
+	}
+	diags = append(diags, planResp.Diagnostics...)
+
+	return r.p.value(r.schema, planResp.PlannedState), planResp.PlannedPrivate, planResp.RequiresReplace, diags
+}
+
+// apply refreshes the resource, plans it with config and applies the plan
+// if it changes anything, replacing the resource when the plan requires it.
+// It returns the diagnostics of every step, stopping at the first error.
+func (r *testResource) apply(config map[string]attr.Value) []*tfprotov6.Diagnostic {
+	r.p.t.Helper()
+
+	var diags []*tfprotov6.Diagnostic
+	if !r.state.IsNull() {
+		diags = r.refresh()
+		if hasErrors(diags) {
+			return diags
+		}
+	}
+
+	configValue := objectValue(r.p.t, r.schema, config)
+	planned, plannedPrivate, requiresReplace, planDiags := r.planConfig(r.state, r.private, configValue)
+	diags = append(diags, planDiags...)
+	if hasErrors(diags) || planned.Equal(r.state) {
+		return diags
+	}
+
+	if len(requiresReplace) > 0 && !r.state.IsNull() {
+		diags = append(diags, r.destroy()...)
+		if hasErrors(diags) {
+			return diags
+		}
+
+		planned, plannedPrivate, _, planDiags = r.planConfig(r.state, r.private, configValue)
+		diags = append(diags, planDiags...)
+		if hasErrors(diags) {
+			return diags
+		}
+	}
+
+	return append(diags, r.applyPlan(planned, plannedPrivate, configValue)...)
+}
+
+// applyPlan applies planned, keeping the new state of the resource unless
+// applying it failed.
+func (r *testResource) applyPlan(planned tftypes.Value, plannedPrivate []byte, configValue tftypes.Value) []*tfprotov6.Diagnostic {
+	r.p.t.Helper()
+
+	resp, err := r.p.server.ApplyResourceChange(r.p.ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.p.dynamicValue(r.schema, r.state),
+		PlannedState:   r.p.dynamicValue(r.schema, planned),
+		Config:         r.p.dynamicValue(r.schema, configValue),
+		PlannedPrivate: plannedPrivate,
+	})
+	if err != nil {
+		r.p.t.Fatalf("applying %s: %s", r.typeName, err)
+	}
+
+	// Like Terraform, keep the state the provider returns even on errors,
+	// as a resource may have been created before the error.
+	if newState := r.p.value(r.schema, resp.NewState); !newState.IsNull() || !hasErrors(resp.Diagnostics) {
+		r.state = newState
+		r.private = resp.Private
+	}
+
+	return resp.Diagnostics
+}
+
+// refresh reads the resource into its state.
+func (r *testResource) refresh() []*tfprotov6.Diagnostic {
+	r.p.t.Helper()
+
+	resp, err := r.p.server.ReadResource(r.p.ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.p.dynamicValue(r.schema, r.state),
+		Private:      r.private,
+	})
+	if err != nil {
+		r.p.t.Fatalf("reading %s: %s", r.typeName, err)
+	}
+	if hasErrors(resp.Diagnostics) {
+		return resp.Diagnostics
+	}
+
+	r.state = r.p.value(r.schema, resp.NewState)
+	r.private = resp.Private
+	return resp.Diagnostics
+}
+
+// destroy deletes the resource.
+func (r *testResource) destroy() []*tfprotov6.Diagnostic {
+	r.p.t.Helper()
+
+	null := tftypes.NewValue(r.schema.ValueType(), nil)
+	planResp, err := r.p.server.PlanResourceChange(r.p.ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.p.dynamicValue(r.schema, r.state),
+		ProposedNewState: r.p.dynamicValue(r.schema, null),
+		Config:           r.p.dynamicValue(r.schema, null),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.p.t.Fatalf("planning the destruction of %s: %s", r.typeName, err)
+	}
+	if hasErrors(planResp.Diagnostics) {
+		return planResp.Diagnostics
+	}
+
+	return append(planResp.Diagnostics, r.applyPlan(null, planResp.PlannedPrivate, null)...)
+}
+
+// importState imports the resource with id, then reads it like Terraform
+// does.
+func (r *testResource) importState(id string) []*tfprotov6.Diagnostic {
+	r.p.t.Helper()
+
+	resp, err := r.p.server.ImportResourceState(r.p.ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.typeName,
+		ID:       id,
+	})
+	if err != nil {
+		r.p.t.Fatalf("importing %s: %s", r.typeName, err)
+	}
+	if hasErrors(resp.Diagnostics) {
+		return resp.Diagnostics
+	}
+	if len(resp.ImportedResources) != 1 {
+		r.p.t.Fatalf("importing %s returned %d resources, expected 1", r.typeName, len(resp.ImportedResources))
+	}
+
+	r.state = r.p.value(r.schema, resp.ImportedResources[0].State)
+	r.private = resp.ImportedResources[0].Private
+	return append(resp.Diagnostics, r.refresh()...)
+}
+
+// planIsEmpty reports whether planning config changes nothing after a
+// refresh, as it should right after an apply.
+func (r *testResource) planIsEmpty(config map[string]attr.Value) bool {
+	r.p.t.Helper()
+
+	requireNoErrors(r.p.t, "refresh", r.refresh())
+	planned, requiresReplace, diags := r.plan(config)
+	requireNoErrors(r.p.t, "plan", diags)
+
+	return planned.Equal(r.state) && len(requiresReplace) == 0
+}
+
+// attribute returns the top-level attribute name of the state.
+func (r *testResource) attribute(name string) tftypes.Value {
+	r.p.t.Helper()
+
+	return attributeValue(r.p.t, r.state, name)
+}
+
+// stringAttribute returns the string attribute name of the state, or "" if
+// it is null.
+func (r *testResource) stringAttribute(name string) string {
+	r.p.t.Helper()
+
+	var value string
+	if attribute := r.attribute(name); !attribute.IsNull() {
+		if err := attribute.As(&value); err != nil {
+			r.p.t.Fatalf("reading %s: %s", name, err)
+		}
+	}
+	return value
+}
+
+// int64Attribute returns the number attribute name of the state, or 0 if it
+// is null.
+func (r *testResource) int64Attribute(name string) int64 {
+	r.p.t.Helper()
+
+	var value int64
+	if attribute := r.attribute(name); !attribute.IsNull() {
+		var number big.Float
+		if err := attribute.As(&number); err != nil {
+			r.p.t.Fatalf("reading %s: %s", name, err)
+		}
+		value, _ = number.Int64()
+	}
+	return value
+}
+
+// listAttribute returns the list of strings attribute name of the state, or
+// nil if it is null. Unknown and null values are returned as "".
+func (r *testResource) listAttribute(name string) []string {
+	r.p.t.Helper()
+
+	attribute := r.attribute(name)
+	if attribute.IsNull() {
+		return nil
+	}
+
+	var elements []tftypes.Value
+	if err := attribute.As(&elements); err != nil {
+		r.p.t.Fatalf("reading %s: %s", name, err)
+	}
+
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		if element.IsKnown() && !element.IsNull() {
+			_ = element.As(&values[i])
+		}
+	}
+	return values
+}
+
+// mapAttribute returns the map of strings attribute name of the state, or
+// nil if it is null. Unknown and null values are returned as "".
+func (r *testResource) mapAttribute(name string) map[string]string {
+	r.p.t.Helper()
+
+	attribute := r.attribute(name)
+	if attribute.IsNull() {
+		return nil
+	}
+
+	var elements map[string]tftypes.Value
+	if err := attribute.As(&elements); err != nil {
+		r.p.t.Fatalf("reading %s: %s", name, err)
+	}
+
+	values := make(map[string]string, len(elements))
+	for key, element := range elements {
+		var value string
+		if element.IsKnown() && !element.IsNull() {
+			_ = element.As(&value)
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// objectValue builds a value of schema from attributes, leaving every other
+// attribute and block null.
+func objectValue(t *testing.T, schema *tfprotov6.Schema, attributes map[string]attr.Value) tftypes.Value {
+	t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	for name, attribute := range attributes {
+		if _, ok := objectType.AttributeTypes[name]; !ok {
+			t.Fatalf("attribute %s is not defined", name)
+		}
+
+		value, err := attribute.ToTerraformValue(context.Background())
+		if err != nil {
+			t.Fatalf("converting %s: %s", name, err)
+		}
+		values[name] = value
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+// proposedNewState merges config into prior the way Terraform does before
+// planning: computed attributes that are not configured keep their prior
+// value, and write-only attributes are never proposed.
+func proposedNewState(t *testing.T, schema *tfprotov6.Schema, prior tftypes.Value, config tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	var configValues map[string]tftypes.Value
+	if err := config.As(&configValues); err != nil {
+		t.Fatalf("decoding config: %s", err)
+	}
+	var priorValues map[string]tftypes.Value
+	if !prior.IsNull() {
+		if err := prior.As(&priorValues); err != nil {
+			t.Fatalf("decoding prior state: %s", err)
+		}
+	}
+
+	values := make(map[string]tftypes.Value, len(configValues))
+	for name, value := range configValues {
+		values[name] = value
+	}
+	for _, attribute := range schema.Block.Attributes {
+		switch {
+		case attribute.WriteOnly:
+			values[attribute.Name] = tftypes.NewValue(attribute.ValueType(), nil)
+		case attribute.Computed && configValues[attribute.Name].IsNull():
+			if prior.IsNull() {
+				values[attribute.Name] = tftypes.NewValue(attribute.ValueType(), nil)
+			} else {
+				values[attribute.Name] = priorValues[attribute.Name]
+			}
+		}
+	}
+
+	return tftypes.NewValue(config.Type(), values)
+}
+
+// attributeValue returns the top-level attribute name of object.
+func attributeValue(t *testing.T, object tftypes.Value, name string) tftypes.Value {
+	t.Helper()
+
+	var values map[string]tftypes.Value
+	if err := object.As(&values); err != nil {
+		t.Fatalf("decoding %s: %s", name, err)
+	}
+	value, ok := values[name]
+	if !ok {
+		t.Fatalf("attribute %s is not defined", name)
+	}
+	return value
+}
+
+// int64Value returns the number attribute name of object, requiring it to be
+// known and not null.
+func int64Value(t *testing.T, object tftypes.Value, name string) int64 {
+	t.Helper()
+
+	var value big.Float
+	if err := attributeValue(t, object, name).As(&value); err != nil {
+		t.Fatalf("reading %s: %s", name, err)
+	}
+	number, _ := value.Int64()
+	return number
+}
+
+// hasErrors reports whether diags hold an error.
+func hasErrors(diags []*tfprotov6.Diagnostic) bool {
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// requireNoErrors fails the test if diags hold an error.
+func requireNoErrors(t *testing.T, action string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", action, diag.Summary, diag.Detail)
+		}
+	}
+}
+
+// findDiagnostic returns the first diagnostic of severity whose summary
+// contains summary, or nil.
+func findDiagnostic(diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, summary string) *tfprotov6.Diagnostic {
+	for _, diag := range diags {
+		if diag.Severity == severity && strings.Contains(diag.Summary, summary) {
+			return diag
+		}
+	}
+	return nil
+}
@@ -2,17 +2,52 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// defaultMaxErrorBodyBytes is used when max_error_body_bytes is not set.
+const defaultMaxErrorBodyBytes = 4096
+
+// defaultMaxConcurrentRequests is used when max_concurrent_requests is not
+// set.
+const defaultMaxConcurrentRequests = 8
+
+const (
+	// defaultMaxRetries is used when max_retries is not set.
+	defaultMaxRetries = 4
+
+	// defaultRetryWaitMin is used when retry_wait_min is not set.
+	defaultRetryWaitMin = 1 * time.Second
+
+	// defaultRetryWaitMax is used when retry_wait_max is not set.
+	defaultRetryWaitMax = 30 * time.Second
 )
 
 // Ensure HuggingFaceSpacesProvider satisfies various provider interfaces.
-var _ provider.Provider = &HuggingFaceSpacesProvider{}
+var (
+	_ provider.Provider                       = &HuggingFaceSpacesProvider{}
+	_ provider.ProviderWithEphemeralResources = &HuggingFaceSpacesProvider{}
+	_ provider.ProviderWithFunctions          = &HuggingFaceSpacesProvider{}
+)
 
 // HuggingFaceSpacesProvider defines the provider implementation.
 type HuggingFaceSpacesProvider struct {
@@ -24,7 +59,19 @@ type HuggingFaceSpacesProvider struct {
 
 // HuggingFaceSpacesProviderModel describes the provider data model.
 type HuggingFaceSpacesProviderModel struct {
-	Token types.String `tfsdk:"token"`
+	Token             types.String `tfsdk:"token"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	EndpointsAPI      types.String `tfsdk:"inference_endpoints_endpoint"`
+	MaxErrorBodyBytes types.Int64  `tfsdk:"max_error_body_bytes"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	MaxConcurrent     types.Int64  `tfsdk:"max_concurrent_requests"`
+	RetryWaitMin      types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax      types.String `tfsdk:"retry_wait_max"`
+	DebugHTTP         types.Bool   `tfsdk:"debug_http"`
+
+	DefaultHardware  types.String `tfsdk:"default_hardware"`
+	DefaultStorage   types.String `tfsdk:"default_storage"`
+	DefaultSleepTime types.Int64  `tfsdk:"default_sleep_time"`
 }
 
 func (p *HuggingFaceSpacesProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -36,10 +83,72 @@ func (p *HuggingFaceSpacesProvider) Schema(ctx context.Context, req provider.Sch
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The Hugging Face API token.",
+				MarkdownDescription: "The Hugging Face API token. Can also be set with the `HF_TOKEN` or `HUGGING_FACE_HUB_TOKEN` environment variables.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the Hugging Face Hub API, for Enterprise Hub or proxy deployments. Can also be set with the `HF_ENDPOINT` environment variable. Defaults to `https://huggingface.co`.",
+				Optional:            true,
+			},
+			"inference_endpoints_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the Inference Endpoints API. Defaults to `https://api.endpoints.huggingface.cloud`.",
+				Optional:            true,
+			},
+			"max_error_body_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of bytes of an API response body included in error messages and logs. Defaults to 4096.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How many times a request the API answers with a 429 or 5xx status code is retried. Defaults to 4.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of requests sent at once when writing the secrets and variables of a space. Defaults to 8.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "The initial wait between two attempts of a request, doubled after every attempt, as a duration such as `1s`. A `Retry-After` header sent by the API takes precedence. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "The maximum wait between two attempts of a request, as a duration such as `30s`. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bodies of all API requests and responses are logged at `TRACE` level, up to `max_error_body_bytes` each, with the values of secrets, variables and files redacted, for attaching to bug reports. Run Terraform with `TF_LOG_PROVIDER=TRACE` to see them.",
+				Optional:            true,
+			},
+			"default_hardware": schema.StringAttribute{
+				MarkdownDescription: "The hardware flavor of spaces that do not set `hardware`, such as `cpu-upgrade`.",
+				Optional:            true,
+				Validators: []validator.String{
+					hardwareValidator{},
+				},
+			},
+			"default_storage": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier of spaces that do not set `storage`, one of `" + strings.Join(storageTiers, "`, `") + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(storageTiers...),
+				},
+			},
+			"default_sleep_time": schema.Int64Attribute{
+				MarkdownDescription: "The sleep time, in seconds, of spaces that do not set `sleep_time`, or `-1` for spaces that never sleep.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(sleepTimeNever),
+				},
+			},
 		},
 	}
 }
@@ -53,17 +162,134 @@ func (p *HuggingFaceSpacesProvider) Configure(ctx context.Context, req provider.
 		return
 	}
 
+	// The token from the configuration takes precedence over the one from
+	// the environment.
+	token := os.Getenv("HF_TOKEN")
+	if token == "" {
+		token = os.Getenv("HUGGING_FACE_HUB_TOKEN")
+	}
+	if !data.Token.IsNull() && !data.Token.IsUnknown() {
+		token = data.Token.ValueString()
+	}
+
 	// Create a new HTTP client with the provided API token
 	client := &http.Client{}
-	if !data.Token.IsNull() && !data.Token.IsUnknown() {
+	if token != "" {
 		client.Transport = &tokenTransport{
-			token:   data.Token.ValueString(),
+			token:   token,
 			wrapped: http.DefaultTransport,
 		}
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	endpoint := os.Getenv("HF_ENDPOINT")
+	if !data.Endpoint.IsNull() && !data.Endpoint.IsUnknown() {
+		endpoint = data.Endpoint.ValueString()
+	}
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+
+	endpointsAPI := defaultInferenceEndpointsEndpoint
+	if !data.EndpointsAPI.IsNull() && !data.EndpointsAPI.IsUnknown() {
+		endpointsAPI = data.EndpointsAPI.ValueString()
+	}
+
+	maxErrorBodyBytes := int64(defaultMaxErrorBodyBytes)
+	if !data.MaxErrorBodyBytes.IsNull() && !data.MaxErrorBodyBytes.IsUnknown() {
+		maxErrorBodyBytes = data.MaxErrorBodyBytes.ValueInt64()
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+
+	maxConcurrent := int64(defaultMaxConcurrentRequests)
+	if !data.MaxConcurrent.IsNull() && !data.MaxConcurrent.IsUnknown() {
+		maxConcurrent = data.MaxConcurrent.ValueInt64()
+	}
+
+	retryWaitMin := parseDuration(data.RetryWaitMin, defaultRetryWaitMin, path.Root("retry_wait_min"), &resp.Diagnostics)
+	retryWaitMax := parseDuration(data.RetryWaitMax, defaultRetryWaitMax, path.Root("retry_wait_max"), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Wait",
+			fmt.Sprintf("retry_wait_min (%s) must not be greater than retry_wait_max (%s).", retryWaitMin, retryWaitMax),
+		)
+		return
+	}
+
+	configured := &providerData{
+		client: hfclient.New(hfclient.Config{
+			HTTPClient:                 client,
+			Endpoint:                   endpoint,
+			InferenceEndpointsEndpoint: endpointsAPI,
+			MaxErrorBodyBytes:          maxErrorBodyBytes,
+			MaxRetries:                 int(maxRetries),
+			RetryWaitMin:               retryWaitMin,
+			RetryWaitMax:               retryWaitMax,
+			DebugHTTP:                  data.DebugHTTP.ValueBool(),
+		}),
+		maxConcurrentRequests: int(maxConcurrent),
+		hardwarePrices:        &hardwarePrices{},
+		spaceDefaults: spaceDefaults{
+			Hardware:  data.DefaultHardware,
+			Storage:   data.DefaultStorage,
+			SleepTime: data.DefaultSleepTime,
+		},
+	}
+
+	resp.DataSourceData = configured
+	resp.ResourceData = configured
+	resp.EphemeralResourceData = configured
+}
+
+// providerData is handed to resources and data sources once the provider has
+// been configured.
+type providerData struct {
+	// client is the client used for all API requests.
+	client *hfclient.Client
+
+	// maxConcurrentRequests bounds the requests sent at once by resources
+	// writing many objects, such as the secrets of a space.
+	maxConcurrentRequests int
+
+	// spaceDefaults are applied to spaces that omit the attributes they set.
+	spaceDefaults spaceDefaults
+
+	// hardwarePrices caches the prices of hardware flavors for the cost
+	// estimates of spaces.
+	hardwarePrices *hardwarePrices
+}
+
+// spaceDefaults holds the default_* attributes of the provider, which are
+// null when not set.
+type spaceDefaults struct {
+	Hardware  types.String
+	Storage   types.String
+	SleepTime types.Int64
+}
+
+// parseDuration parses a duration set in the provider configuration,
+// returning fallback if it is not set.
+func parseDuration(value types.String, fallback time.Duration, attr path.Path, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attr, "Invalid Duration", fmt.Sprintf("Unable to parse %q as a duration, got error: %s", value.ValueString(), err))
+		return fallback
+	}
+
+	return duration
 }
 
 type tokenTransport struct {
@@ -79,12 +305,59 @@ func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 func (p *HuggingFaceSpacesProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSpaceResource,
+		NewModelResource,
+		NewDatasetResource,
+		NewModelCardResource,
+		NewDatasetCardResource,
+		NewSpaceSecretResource,
+		NewSpaceStorageResource,
+		NewSpaceDomainResource,
+		NewSpaceVariableResource,
+		NewSpaceFileResource,
+		NewRepoCommitResource,
+		NewRepoBranchResource,
+		NewRepoTagResource,
+		NewRepoCollaboratorResource,
+		NewDiscussionResource,
+		NewInferenceEndpointResource,
+		NewCollectionResource,
+		NewCollectionItemResource,
+		NewWebhookResource,
+		NewAccessTokenResource,
+		NewOrgMemberResource,
 	}
 }
 
 func (p *HuggingFaceSpacesProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSpaceDataSource,
+		NewSpacesDataSource,
+		NewModelDataSource,
+		NewModelsDataSource,
+		NewSpaceBuildLogsDataSource,
+		NewSpaceRuntimeDataSource,
+		NewSpaceSecretsDataSource,
+		NewSpaceVariablesDataSource,
+		NewWhoAmIDataSource,
+		NewRateLimitDataSource,
+		NewOrganizationDataSource,
+		NewUserDataSource,
+		NewHardwareFlavorsDataSource,
+		NewRepoFileDataSource,
+		NewRepoFilesDataSource,
+	}
+}
+
+func (p *HuggingFaceSpacesProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
+func (p *HuggingFaceSpacesProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseRepoIDFunction,
+		NewSpaceURLFunction,
 	}
 }
 
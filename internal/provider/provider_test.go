@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"huggingface-spaces": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccCassette points the providers of the test at the cassette name
+// through HF_ENDPOINT, so that they replay the interactions stored in
+// testdata/cassettes, or record them from the real Hub when HF_RECORD is set.
+// Configurations using it must leave endpoint and token unset.
+func testAccCassette(t *testing.T, name string) *testutil.Cassette {
+	t.Helper()
+
+	cassette := testutil.NewCassette(t, name)
+	t.Setenv("HF_ENDPOINT", cassette.URL())
+	if !cassette.Recording() {
+		t.Setenv("HF_TOKEN", testutil.Token)
+	}
+
+	return cassette
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &RateLimitDataSource{}
+
+// RateLimitDataSource defines the data source implementation.
+type RateLimitDataSource struct {
+	client *hfclient.Client
+}
+
+// RateLimitDataSourceModel describes the data source data model.
+type RateLimitDataSourceModel struct {
+	Limit     types.Int64  `tfsdk:"limit"`
+	Remaining types.Int64  `tfsdk:"remaining"`
+	Reset     types.String `tfsdk:"reset"`
+}
+
+func (d *RateLimitDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rate_limit"
+}
+
+func (d *RateLimitDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the API rate limit quota of the provider token, as reported by the `X-RateLimit-*` headers of the Hub, to debug large applies. All attributes are null if the Hub does not report a rate limit.",
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "How many requests may be sent in the current window.",
+				Computed:            true,
+			},
+			"remaining": schema.Int64Attribute{
+				MarkdownDescription: "How many requests are left in the current window.",
+				Computed:            true,
+			},
+			"reset": schema.StringAttribute{
+				MarkdownDescription: "When the quota is restored, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RateLimitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *RateLimitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := RateLimitDataSourceModel{
+		Limit:     types.Int64Null(),
+		Remaining: types.Int64Null(),
+		Reset:     types.StringNull(),
+	}
+
+	// The quota is refreshed with the cheapest authenticated request, so that
+	// it is reported even when nothing else was sent yet.
+	if _, err := d.client.WhoAmI(ctx); err != nil {
+		addClientError(&resp.Diagnostics, "read rate limit", err)
+		return
+	}
+
+	if rateLimit := d.client.RateLimit(); rateLimit != nil {
+		tflog.Debug(ctx, fmt.Sprintf("%d of %d API requests remaining", rateLimit.Remaining, rateLimit.Limit))
+
+		data.Limit = types.Int64Value(rateLimit.Limit)
+		data.Remaining = types.Int64Value(rateLimit.Remaining)
+		if !rateLimit.Reset.IsZero() {
+			data.Reset = types.StringValue(rateLimit.Reset.UTC().Format(time.RFC3339))
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewRateLimitDataSource() datasource.DataSource {
+	return &RateLimitDataSource{}
+}
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// refImportedPrivateKey is the private state key marking branches and tags
+// that were imported, whose revision and message cannot be read back.
+const refImportedPrivateKey = "imported"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoBranchResource{}
+	_ resource.ResourceWithConfigure   = &RepoBranchResource{}
+	_ resource.ResourceWithImportState = &RepoBranchResource{}
+)
+
+// RepoBranchResource defines the resource implementation.
+type RepoBranchResource struct {
+	client *hfclient.Client
+}
+
+// RepoBranchResourceModel describes the resource data model.
+type RepoBranchResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	RepoID    types.String `tfsdk:"repo_id"`
+	RepoType  types.String `tfsdk:"repo_type"`
+	Name      types.String `tfsdk:"name"`
+	Revision  types.String `tfsdk:"revision"`
+	CommitSHA types.String `tfsdk:"commit_sha"`
+}
+
+func (r *RepoBranchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_branch"
+}
+
+func (r *RepoBranchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a branch of a space, model or dataset repository.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the branch, in the form `repo_type:namespace/name@branch`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`. Changing this forces a new branch to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`. Changing this forces a new branch to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hfclient.RepoTypeSpace)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the branch. Changing this forces a new branch to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit SHA the branch starts from. Defaults to the head of the default branch. Changing this forces a new branch to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					refRequiresReplace(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the commit the branch currently points at.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoBranchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RepoBranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := hfclient.RepoType(data.RepoType.ValueString())
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating branch %s of %s", data.Name.ValueString(), data.RepoID.ValueString()))
+
+	err := r.client.CreateBranch(ctx, repoType, data.RepoID.ValueString(), data.Name.ValueString(), data.Revision.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("create branch %s", data.Name.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), data.Name.ValueString()))
+
+	// The commit the branch starts from is only known once it was created.
+	ref, found := readGitRef(ctx, r.client, repoType, data.RepoID.ValueString(), "branch", data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Branch %s was not found after it was created", data.ID.ValueString()))
+		return
+	}
+	data.CommitSHA = types.StringValue(ref.TargetCommit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ref, found := readGitRef(ctx, r.client, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), "branch", data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("Branch %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.CommitSHA = types.StringValue(ref.TargetCommit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every configurable attribute forces a new branch to be created, except
+	// the revision of an imported branch, which is only recorded.
+	var state RepoBranchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CommitSHA = state.CommitSHA
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, refImportedPrivateKey, nil)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A branch that is already gone, or whose repository is, is as good as
+	// deleted.
+	err := r.client.DeleteBranch(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Name.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete branch %s", data.Name.ValueString()), err)
+		return
+	}
+}
+
+func (r *RepoBranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRepoRef(ctx, req, resp)
+}
+
+// readGitRef retrieves the branch or tag name of a repository, reporting
+// whether it exists. A missing repository is reported as a missing ref.
+func readGitRef(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, kind string, name string, diags *diag.Diagnostics) (*hfclient.GitRef, bool) {
+	refs, err := client.ListRefs(ctx, repoType, repoID)
+	if hfclient.IsNotFound(err) {
+		return nil, false
+	}
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("list refs of %s", repoID), err)
+		return nil, false
+	}
+
+	list := refs.Branches
+	if kind == "tag" {
+		list = refs.Tags
+	}
+
+	for _, ref := range list {
+		if ref.Name == name {
+			return &ref, true
+		}
+	}
+
+	return nil, false
+}
+
+// importRepoRef imports a branch or tag by its ID, of the form
+// repo_type:namespace/name@ref.
+func importRepoRef(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, ref, ok := splitRepoRefID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form repo_type:namespace/name@ref, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), ref)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, refImportedPrivateKey, []byte(`true`))...)
+}
+
+// refRequiresReplace returns a plan modifier that forces a new branch or tag
+// to be created when the attribute changes. A branch or tag that was just
+// imported has no value for the attribute, so the configured one is recorded
+// in place instead.
+func refRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			imported, diags := req.Private.GetKey(ctx, refImportedPrivateKey)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = imported == nil || !req.StateValue.IsNull()
+		},
+		"Changing this forces a new resource to be created, unless it was just imported.",
+		"Changing this forces a new resource to be created, unless it was just imported.",
+	)
+}
+
+func NewRepoBranchResource() resource.Resource {
+	return &RepoBranchResource{}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRepoBranchType is the type name of the repo branch resource.
+const testRepoBranchType = "huggingface-spaces_repo_branch"
+
+func TestRepoBranchResourceImport(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodGet, "/api/models/testuser/classifier/refs", http.StatusOK,
+		`{"branches": [{"name": "main", "targetCommit": "aaaa"}, {"name": "staging", "targetCommit": "bbbb"}]}`)
+
+	branch := newMockProvider(t, api).resource(testRepoBranchType)
+	requireNoErrors(t, "import", branch.importState("model:testuser/classifier@staging"))
+
+	// The revision a branch started from cannot be read back, so importing
+	// it records the configured one instead of creating the branch again.
+	config := map[string]attr.Value{
+		"repo_id":   types.StringValue("testuser/classifier"),
+		"repo_type": types.StringValue("model"),
+		"name":      types.StringValue("staging"),
+		"revision":  types.StringValue("v1"),
+	}
+	_, requiresReplace, diags := branch.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Fatalf("importing requires replacing %v", requiresReplace)
+	}
+	requireNoErrors(t, "apply", branch.apply(config))
+
+	if requests := api.requestsTo(http.MethodPost, ""); len(requests) != 0 {
+		t.Errorf("sent %d requests to create the branch again, expected none", len(requests))
+	}
+	if !branch.planIsEmpty(config) {
+		t.Error("plan is not empty after import")
+	}
+
+	// Once recorded, changing the revision creates the branch again.
+	config["revision"] = types.StringValue("v2")
+	_, requiresReplace, diags = branch.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 1 || !requiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("revision")) {
+		t.Errorf("changing the revision requires replacing %v, expected revision", requiresReplace)
+	}
+}
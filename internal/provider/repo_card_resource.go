@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// cardImportedPrivateKey is the private state key marking cards that were
+// just imported, whose metadata is all read back once.
+const cardImportedPrivateKey = "imported"
+
+// cardField is a key of the card metadata managed by a card resource.
+type cardField struct {
+	// Attribute is the name of the attribute, and Key the frontmatter key
+	// it is written to.
+	Attribute string
+	Key       string
+
+	// List is set for lists of strings, such as tags.
+	List bool
+
+	Description string
+}
+
+// modelCardFields are the card metadata keys managed by the model card
+// resource.
+var modelCardFields = []cardField{
+	{Attribute: "license", Key: "license", Description: "The license of the model, such as `apache-2.0`."},
+	{Attribute: "pipeline_tag", Key: "pipeline_tag", Description: "The task of the model, such as `text-generation`, which decides the widget and API the Hub serves it with."},
+	{Attribute: "library_name", Key: "library_name", Description: "The library the model is loaded with, such as `transformers`."},
+	{Attribute: "tags", Key: "tags", List: true, Description: "Tags of the model."},
+	{Attribute: "language", Key: "language", List: true, Description: "Languages the model supports, as ISO 639-1 codes such as `en`."},
+	{Attribute: "datasets", Key: "datasets", List: true, Description: "IDs of the datasets the model was trained on."},
+	{Attribute: "base_model", Key: "base_model", List: true, Description: "IDs of the models the model was fine-tuned or derived from."},
+}
+
+// datasetCardFields are the card metadata keys managed by the dataset card
+// resource.
+var datasetCardFields = []cardField{
+	{Attribute: "license", Key: "license", Description: "The license of the dataset, such as `cc-by-4.0`."},
+	{Attribute: "pretty_name", Key: "pretty_name", Description: "The human readable name of the dataset."},
+	{Attribute: "tags", Key: "tags", List: true, Description: "Tags of the dataset."},
+	{Attribute: "language", Key: "language", List: true, Description: "Languages of the dataset, as ISO 639-1 codes such as `en`."},
+	{Attribute: "task_categories", Key: "task_categories", List: true, Description: "Tasks the dataset can be used for, such as `text-classification`."},
+	{Attribute: "size_categories", Key: "size_categories", List: true, Description: "The size category of the dataset, such as `1K<n<10K`."},
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoCardResource{}
+	_ resource.ResourceWithConfigure   = &RepoCardResource{}
+	_ resource.ResourceWithImportState = &RepoCardResource{}
+)
+
+// RepoCardResource defines the resource implementation, shared by the model
+// and dataset card resources. As the managed keys differ between both, the
+// attributes are accessed by path rather than through a model struct.
+type RepoCardResource struct {
+	client   *hfclient.Client
+	repoType hfclient.RepoType
+	fields   []cardField
+}
+
+func (r *RepoCardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_%s_card", req.ProviderTypeName, r.repoType)
+}
+
+func (r *RepoCardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The ID of the %s, in the form `namespace/name`.", r.repoType),
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"repo_id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The ID of the %s, in the form `namespace/name`. Changing this manages the card of another %s.", r.repoType, r.repoType),
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(repoIDRegexp, "must be of the form namespace/name"),
+			},
+		},
+	}
+
+	for _, field := range r.fields {
+		if field.List {
+			attributes[field.Attribute] = schema.ListAttribute{
+				MarkdownDescription: field.Description,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			}
+			continue
+		}
+
+		attributes[field.Attribute] = schema.StringAttribute{
+			MarkdownDescription: field.Description,
+			Optional:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages the metadata of the card of a %s, the YAML frontmatter of its `README.md`. Only the keys of the attributes that are set are managed: other keys and the body of the card are left untouched, and destroying the resource leaves the card as it is.", r.repoType),
+		Attributes:          attributes,
+	}
+}
+
+func (r *RepoCardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RepoCardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var repoID types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("repo_id"), &repoID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.writeCard(ctx, repoID.ValueString(), req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoID)...)
+}
+
+func (r *RepoCardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var repoID types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("repo_id"), &repoID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Missing cards are read as empty ones, so the repository itself is
+	// looked up to find out whether it was deleted.
+	var err error
+	if r.repoType == hfclient.RepoTypeDataset {
+		_, err = r.client.GetDataset(ctx, repoID.ValueString())
+	} else {
+		_, err = r.client.GetModel(ctx, repoID.ValueString())
+	}
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("%s %s not found, removing card from state", r.repoType, repoID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read %s", r.repoType), err)
+		return
+	}
+
+	card, err := loadRepoCard(ctx, r.client, r.repoType, repoID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read %s card", r.repoType), err)
+		return
+	}
+
+	// Cards that were just imported have no managed keys yet, so every key
+	// present in the card is read back once.
+	imported, diags := req.Private.GetKey(ctx, cardImportedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if imported != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, cardImportedPrivateKey, nil)...)
+	}
+
+	for _, field := range r.fields {
+		attribute := path.Root(field.Attribute)
+
+		var current attr.Value
+		if field.List {
+			var list types.List
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, attribute, &list)...)
+			current = list
+		} else {
+			var value types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, attribute, &value)...)
+			current = value
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if current.IsNull() && imported == nil {
+			continue
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attribute, readCardField(ctx, card, field, &resp.Diagnostics))...)
+	}
+}
+
+func (r *RepoCardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var repoID types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("repo_id"), &repoID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.writeCard(ctx, repoID.ValueString(), req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+}
+
+func (r *RepoCardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var repoID types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("repo_id"), &repoID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The metadata is left in the card, as removing it could break the
+	// repository, for instance by removing its license.
+	tflog.Debug(ctx, fmt.Sprintf("Removing card of %s %s from state, leaving it as it is", r.repoType, repoID.ValueString()))
+}
+
+func (r *RepoCardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !repoIDRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, cardImportedPrivateKey, []byte(`true`))...)
+}
+
+// writeCard sets the keys of the attributes that are set in plan in the card
+// of the repository repoID, committing the card if it changed.
+func (r *RepoCardResource) writeCard(ctx context.Context, repoID string, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	card, err := loadRepoCard(ctx, r.client, r.repoType, repoID)
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("read %s card", r.repoType), err)
+		return
+	}
+
+	for _, field := range r.fields {
+		attribute := path.Root(field.Attribute)
+
+		if field.List {
+			var list types.List
+			diags.Append(plan.GetAttribute(ctx, attribute, &list)...)
+			if list.IsNull() {
+				continue
+			}
+
+			values := []string{}
+			diags.Append(list.ElementsAs(ctx, &values, false)...)
+			card.set(field.Key, values)
+			continue
+		}
+
+		var value types.String
+		diags.Append(plan.GetAttribute(ctx, attribute, &value)...)
+		if !value.IsNull() {
+			card.set(field.Key, value.ValueString())
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating card metadata of %s %s", r.repoType, repoID))
+
+	if err := card.save(ctx, r.client, r.repoType, repoID); err != nil {
+		addClientError(diags, fmt.Sprintf("update %s card", r.repoType), err)
+	}
+}
+
+// readCardField returns the value of field in card, or null if it is not
+// set. Lists written as a single string, such as language: en, are read as
+// a list of one element.
+func readCardField(ctx context.Context, card *repoCard, field cardField, diags *diag.Diagnostics) attr.Value {
+	node := card.get(field.Key)
+
+	if !field.List {
+		if node == nil {
+			return types.StringNull()
+		}
+		return types.StringValue(node.Value)
+	}
+
+	if node == nil {
+		return types.ListNull(types.StringType)
+	}
+
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		var value string
+		if err := node.Decode(&value); err != nil {
+			return types.ListNull(types.StringType)
+		}
+		values = []string{value}
+	}
+
+	list, d := stringListValue(ctx, values)
+	diags.Append(d...)
+	return list
+}
+
+func NewModelCardResource() resource.Resource {
+	return &RepoCardResource{
+		repoType: hfclient.RepoTypeModel,
+		fields:   modelCardFields,
+	}
+}
+
+func NewDatasetCardResource() resource.Resource {
+	return &RepoCardResource{
+		repoType: hfclient.RepoTypeDataset,
+		fields:   datasetCardFields,
+	}
+}
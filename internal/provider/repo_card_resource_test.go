@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+func TestRepoCardResourceModel(t *testing.T) {
+	hub := testutil.NewHub(t)
+	hub.PutRepo(testutil.Repo{
+		Type: hfclient.RepoTypeModel,
+		ID:   "testutil/classifier",
+		Files: map[string][]byte{
+			"README.md": []byte("---\nlicense: mit\n---\n# Classifier\n"),
+		},
+	})
+	card := newHubProvider(t, hub).resource("huggingface-spaces_model_card")
+
+	config := map[string]attr.Value{
+		"repo_id":      types.StringValue("testutil/classifier"),
+		"pipeline_tag": types.StringValue("text-classification"),
+		"tags":         stringList("sentiment"),
+	}
+	requireNoErrors(t, "create", card.apply(config))
+
+	readme := string(hub.Repo(hfclient.RepoTypeModel, "testutil/classifier").Files["README.md"])
+	for _, expected := range []string{"license: mit", "pipeline_tag: text-classification", "- sentiment", "# Classifier"} {
+		if !strings.Contains(readme, expected) {
+			t.Errorf("card %q does not contain %q", readme, expected)
+		}
+	}
+	if id := card.stringAttribute("id"); id != "testutil/classifier" {
+		t.Errorf("id = %q, expected testutil/classifier", id)
+	}
+	if !card.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	config["pipeline_tag"] = types.StringValue("text-generation")
+	requireNoErrors(t, "update", card.apply(config))
+
+	readme = string(hub.Repo(hfclient.RepoTypeModel, "testutil/classifier").Files["README.md"])
+	if !strings.Contains(readme, "pipeline_tag: text-generation") || !strings.Contains(readme, "license: mit") {
+		t.Errorf("card %q was not updated", readme)
+	}
+	if !card.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+}
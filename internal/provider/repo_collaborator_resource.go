@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoCollaboratorResource{}
+	_ resource.ResourceWithConfigure   = &RepoCollaboratorResource{}
+	_ resource.ResourceWithImportState = &RepoCollaboratorResource{}
+)
+
+// collaboratorTypes lists the kinds of principals that can be granted access
+// to a repository.
+var collaboratorTypes = []string{"user", "team"}
+
+// collaboratorRoles lists the roles principals can be granted on a
+// repository.
+var collaboratorRoles = []string{"read", "write", "admin"}
+
+// RepoCollaboratorResource defines the resource implementation.
+type RepoCollaboratorResource struct {
+	client *hfclient.Client
+}
+
+// RepoCollaboratorResourceModel describes the resource data model.
+type RepoCollaboratorResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	RepoID        types.String `tfsdk:"repo_id"`
+	RepoType      types.String `tfsdk:"repo_type"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+	Principal     types.String `tfsdk:"principal"`
+	Role          types.String `tfsdk:"role"`
+}
+
+func (r *RepoCollaboratorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_collaborator"
+}
+
+func (r *RepoCollaboratorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a user or team access to a private space, model or dataset repository.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the grant, in the form `repo_type:namespace/name@principal_type/principal`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`. Changing this forces a new grant to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`. Changing this forces a new grant to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hfclient.RepoTypeSpace)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal_type": schema.StringAttribute{
+				MarkdownDescription: "Whether access is granted to a `user` or a `team` of the organization owning the repository. Defaults to `user`. Changing this forces a new grant to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("user"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(collaboratorTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "The name of the user or team. Changing this forces a new grant to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role granted on the repository, one of `read`, `write` or `admin`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(collaboratorRoles...),
+				},
+			},
+		},
+	}
+}
+
+func (r *RepoCollaboratorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RepoCollaboratorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoCollaboratorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Granting %s %s %s access to %s", data.PrincipalType.ValueString(), data.Principal.ValueString(), data.Role.ValueString(), data.RepoID.ValueString()))
+
+	if err := r.setCollaborator(ctx, data); err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("grant %s access", data.Principal.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), data.PrincipalType.ValueString()+"/"+data.Principal.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCollaboratorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoCollaboratorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collaborators, err := r.client.ListCollaborators(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Repository %s not found, removing grant from state", data.RepoID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list collaborators of %s", data.RepoID.ValueString()), err)
+		return
+	}
+
+	var found *hfclient.Collaborator
+	for _, collaborator := range collaborators {
+		if collaborator.Type == data.PrincipalType.ValueString() && collaborator.Name == data.Principal.ValueString() {
+			found = &collaborator
+			break
+		}
+	}
+	if found == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Grant %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Role = types.StringValue(found.Role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCollaboratorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RepoCollaboratorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the role can change, and granting access again replaces it.
+	if err := r.setCollaborator(ctx, data); err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("update role of %s", data.Principal.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCollaboratorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoCollaboratorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveCollaborator(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.PrincipalType.ValueString(), data.Principal.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("revoke access of %s", data.Principal.ValueString()), err)
+		return
+	}
+}
+
+func (r *RepoCollaboratorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, principal, ok := splitRepoRefID(req.ID)
+	principalType, name, found := strings.Cut(principal, "/")
+	if !ok || !found || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form repo_type:namespace/name@principal_type/principal, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_type"), principalType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), name)...)
+}
+
+// setCollaborator grants the principal of data its role on the repository.
+func (r *RepoCollaboratorResource) setCollaborator(ctx context.Context, data *RepoCollaboratorResourceModel) error {
+	return r.client.SetCollaborator(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), hfclient.Collaborator{
+		Type: data.PrincipalType.ValueString(),
+		Name: data.Principal.ValueString(),
+		Role: data.Role.ValueString(),
+	})
+}
+
+func NewRepoCollaboratorResource() resource.Resource {
+	return &RepoCollaboratorResource{}
+}
@@ -0,0 +1,515 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &RepoCommitResource{}
+	_ resource.ResourceWithConfigure      = &RepoCommitResource{}
+	_ resource.ResourceWithValidateConfig = &RepoCommitResource{}
+	_ resource.ResourceWithModifyPlan     = &RepoCommitResource{}
+)
+
+// repoTypes lists the repository types files can be committed to.
+var repoTypes = []string{
+	string(hfclient.RepoTypeModel),
+	string(hfclient.RepoTypeDataset),
+	string(hfclient.RepoTypeSpace),
+}
+
+// RepoCommitResource defines the resource implementation.
+type RepoCommitResource struct {
+	client *hfclient.Client
+}
+
+// RepoCommitResourceModel describes the resource data model.
+type RepoCommitResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	RepoID        types.String `tfsdk:"repo_id"`
+	RepoType      types.String `tfsdk:"repo_type"`
+	Branch        types.String `tfsdk:"branch"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	Files         types.Set    `tfsdk:"files"`
+	FilesSHA256   types.Map    `tfsdk:"files_sha256"`
+	CommitSHA     types.String `tfsdk:"commit_sha"`
+}
+
+// RepoCommitFileModel describes a file of a commit.
+type RepoCommitFileModel struct {
+	Path    types.String `tfsdk:"path"`
+	Content types.String `tfsdk:"content"`
+	Source  types.String `tfsdk:"source"`
+}
+
+func (r *RepoCommitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_commit"
+}
+
+func (r *RepoCommitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Commits a set of files to a repository in a single atomic commit, so that deploying a whole app rebuilds a space only once. Files removed from the set are deleted from the repository by the next commit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the commit resource, in the form `namespace/name@branch`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`. Changing this forces a new commit to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`. Changing this forces a new commit to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hfclient.RepoTypeSpace)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "The branch to commit to. Defaults to `main`. Changing this forces a new commit to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultBranch),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				MarkdownDescription: "The message of the commits. Defaults to a message counting the changed files.",
+				Optional:            true,
+			},
+			"files": schema.SetNestedAttribute{
+				MarkdownDescription: "The files to commit.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							MarkdownDescription: "The path of the file in the repository.",
+							Required:            true,
+						},
+						"content": schema.StringAttribute{
+							MarkdownDescription: "The content of the file. Conflicts with `source`.",
+							Optional:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The path of a local file to read the content from. Conflicts with `content`.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"files_sha256": schema.MapAttribute{
+				MarkdownDescription: "The SHA-256 of the content of every file in the repository by path, used to detect changes made outside of Terraform and to local `source` files.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the last commit made.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoCommitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RepoCommitResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Files.IsUnknown() || data.Files.IsNull() {
+		return
+	}
+
+	var files []RepoCommitFileModel
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	paths := make(map[string]bool)
+	for _, file := range files {
+		if file.Content.IsUnknown() || file.Source.IsUnknown() {
+			continue
+		}
+
+		if file.Content.IsNull() == file.Source.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files"),
+				"Invalid File",
+				fmt.Sprintf("Exactly one of content or source must be set for file %q.", file.Path.ValueString()),
+			)
+		}
+
+		if file.Path.IsUnknown() {
+			continue
+		}
+		if paths[file.Path.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files"),
+				"Duplicate File",
+				fmt.Sprintf("File %q is declared more than once.", file.Path.ValueString()),
+			)
+		}
+		paths[file.Path.ValueString()] = true
+	}
+}
+
+func (r *RepoCommitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The hashes of the files are planned from the configuration, reading
+	// local sources, so that a change to a source file or to the repository
+	// shows up as a change to files_sha256.
+	hashes, known := r.fileHashes(ctx, plan.Files, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filesSHA256 := types.MapUnknown(types.StringType)
+	if known {
+		value, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		filesSHA256 = value
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("files_sha256"), filesSHA256)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state RepoCommitResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without a change to any file there is nothing to commit, so the last
+	// commit stays.
+	if filesSHA256.Equal(state.FilesSHA256) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("commit_sha"), state.CommitSHA)...)
+	}
+}
+
+func (r *RepoCommitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.commit(ctx, data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.RepoID.ValueString() + "@" + data.Branch.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCommitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes := make(map[string]string)
+	resp.Diagnostics.Append(data.FilesSHA256.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Files changed or deleted outside of Terraform are recorded with their
+	// current hash, or dropped, so that the next plan commits them again.
+	repoType := hfclient.RepoType(data.RepoType.ValueString())
+	for filePath := range hashes {
+		content, err := r.client.DownloadFile(ctx, repoType, data.RepoID.ValueString(), data.Branch.ValueString(), filePath)
+		if hfclient.IsNotFound(err) {
+			tflog.Debug(ctx, fmt.Sprintf("File %s not found in %s", filePath, data.RepoID.ValueString()))
+			delete(hashes, filePath)
+			continue
+		}
+		if err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("read file %s", filePath), err)
+			return
+		}
+
+		hashes[filePath] = contentSHA256(content)
+	}
+
+	filesSHA256, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.FilesSHA256 = filesSHA256
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCommitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state RepoCommitResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := make(map[string]string)
+	resp.Diagnostics.Append(state.FilesSHA256.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.commit(ctx, data, previous, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CommitSHA.IsUnknown() {
+		data.CommitSHA = state.CommitSHA
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoCommitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoCommitResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes := make(map[string]string)
+	resp.Diagnostics.Append(data.FilesSHA256.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() || len(hashes) == 0 {
+		return
+	}
+
+	var operations []hfclient.CommitOperation
+	for _, filePath := range sortedKeys(hashes) {
+		operations = append(operations, hfclient.CommitOperation{Path: filePath, Delete: true})
+	}
+
+	_, err := r.client.CreateCommit(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Branch.ValueString(), hfclient.CreateCommitRequest{
+		Summary:    fmt.Sprintf("Delete %d files", len(operations)),
+		Operations: operations,
+	})
+
+	// Files whose repository is already gone are as good as deleted.
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete files of %s", data.RepoID.ValueString()), err)
+		return
+	}
+}
+
+// commit creates a single commit writing every file of data whose hash
+// differs from previous, and deleting every file of previous that is no
+// longer part of data. Nothing is committed when no file changed.
+func (r *RepoCommitResource) commit(ctx context.Context, data *RepoCommitResourceModel, previous map[string]string, diags *diag.Diagnostics) {
+	contents := r.fileContents(ctx, data.Files, diags)
+	if diags.HasError() {
+		return
+	}
+
+	hashes := make(map[string]string, len(contents))
+	var operations []hfclient.CommitOperation
+	for _, filePath := range sortedKeys(contents) {
+		hash := contentSHA256(contents[filePath])
+		hashes[filePath] = hash
+
+		if previous[filePath] == hash {
+			continue
+		}
+		operations = append(operations, hfclient.CommitOperation{Path: filePath, Content: contents[filePath]})
+	}
+	for _, filePath := range sortedKeys(previous) {
+		if _, ok := contents[filePath]; !ok {
+			operations = append(operations, hfclient.CommitOperation{Path: filePath, Delete: true})
+		}
+	}
+
+	filesSHA256, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	data.FilesSHA256 = filesSHA256
+
+	if len(operations) == 0 {
+		return
+	}
+
+	summary := fmt.Sprintf("Update %d files", len(operations))
+	if !data.CommitMessage.IsNull() {
+		summary = data.CommitMessage.ValueString()
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Committing %d files to %s", len(operations), data.RepoID.ValueString()))
+
+	commit, err := r.client.CreateCommit(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Branch.ValueString(), hfclient.CreateCommitRequest{
+		Summary:    summary,
+		Operations: operations,
+	})
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("commit files to %s", data.RepoID.ValueString()), err)
+		return
+	}
+
+	data.CommitSHA = types.StringValue(commit.CommitOID)
+}
+
+// fileContents returns the content of every file in files by path, reading
+// local sources.
+func (r *RepoCommitResource) fileContents(ctx context.Context, files types.Set, diags *diag.Diagnostics) map[string][]byte {
+	var models []RepoCommitFileModel
+	diags.Append(files.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	contents := make(map[string][]byte, len(models))
+	for _, file := range models {
+		if !file.Source.IsNull() {
+			content, err := os.ReadFile(file.Source.ValueString())
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("files"),
+					"Unable to Read Source",
+					fmt.Sprintf("Unable to read source of file %q, got error: %s", file.Path.ValueString(), err),
+				)
+				continue
+			}
+			contents[file.Path.ValueString()] = content
+			continue
+		}
+
+		contents[file.Path.ValueString()] = []byte(file.Content.ValueString())
+	}
+
+	return contents
+}
+
+// fileHashes returns the SHA-256 of every file in files by path. It reports
+// false when the content of any file is not known yet.
+func (r *RepoCommitResource) fileHashes(ctx context.Context, files types.Set, diags *diag.Diagnostics) (map[string]string, bool) {
+	if files.IsUnknown() {
+		return nil, false
+	}
+
+	var models []RepoCommitFileModel
+	diags.Append(files.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, false
+	}
+
+	for _, file := range models {
+		if file.Path.IsUnknown() || file.Content.IsUnknown() || file.Source.IsUnknown() {
+			return nil, false
+		}
+	}
+
+	contents := r.fileContents(ctx, files, diags)
+	if diags.HasError() {
+		return nil, false
+	}
+
+	hashes := make(map[string]string, len(contents))
+	for filePath, content := range contents {
+		hashes[filePath] = contentSHA256(content)
+	}
+
+	return hashes, true
+}
+
+// sortedKeys returns the keys of m in order, so that commits list their
+// operations deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func NewRepoCommitResource() resource.Resource {
+	return &RepoCommitResource{}
+}
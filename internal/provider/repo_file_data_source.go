@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// defaultMaxFileSize is the size in bytes of the largest file the repo file
+// data source downloads unless configured otherwise.
+const defaultMaxFileSize = 1 << 20
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &RepoFileDataSource{}
+
+// RepoFileDataSource defines the data source implementation.
+type RepoFileDataSource struct {
+	client *hfclient.Client
+}
+
+// RepoFileDataSourceModel describes the data source data model.
+type RepoFileDataSourceModel struct {
+	RepoID        types.String `tfsdk:"repo_id"`
+	RepoType      types.String `tfsdk:"repo_type"`
+	Revision      types.String `tfsdk:"revision"`
+	Path          types.String `tfsdk:"path"`
+	MaxSize       types.Int64  `tfsdk:"max_size"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Size          types.Int64  `tfsdk:"size"`
+	LFS           types.Bool   `tfsdk:"lfs"`
+	BlobSHA       types.String `tfsdk:"blob_sha"`
+	LastCommitSHA types.String `tfsdk:"last_commit_sha"`
+}
+
+func (d *RepoFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_file"
+}
+
+func (d *RepoFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a single file of a space, model or dataset repository at a revision.",
+		Attributes: map[string]schema.Attribute{
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit SHA to read the file from. Defaults to `main`.",
+				Optional:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the file, relative to the root of the repository.",
+				Required:            true,
+			},
+			"max_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The size in bytes of the largest file to download. Reading a larger file fails. Defaults to `%d`.", defaultMaxFileSize),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the file. Null if the file is not valid UTF-8, use `content_base64` instead.",
+				Computed:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded content of the file.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the file in bytes.",
+				Computed:            true,
+			},
+			"lfs": schema.BoolAttribute{
+				MarkdownDescription: "Whether the file is stored in Git LFS.",
+				Computed:            true,
+			},
+			"blob_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the Git blob of the file.",
+				Computed:            true,
+			},
+			"last_commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the last commit touching the file.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RepoFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *RepoFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RepoFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := hfclient.RepoTypeSpace
+	if !data.RepoType.IsNull() {
+		repoType = hfclient.RepoType(data.RepoType.ValueString())
+	}
+
+	revision := defaultBranch
+	if !data.Revision.IsNull() {
+		revision = data.Revision.ValueString()
+	}
+
+	maxSize := int64(defaultMaxFileSize)
+	if !data.MaxSize.IsNull() {
+		maxSize = data.MaxSize.ValueInt64()
+	}
+
+	repoID := data.RepoID.ValueString()
+	filePath := data.Path.ValueString()
+
+	entry, err := d.client.GetPathInfo(ctx, repoType, repoID, revision, filePath)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read file %s of %s", filePath, repoID), err)
+		return
+	}
+	if entry == nil || entry.Type != "file" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"File Not Found",
+			fmt.Sprintf("There is no file %s in %s at revision %s.", filePath, repoID, revision),
+		)
+		return
+	}
+
+	// Check the size up front rather than downloading a large file only to
+	// discard it.
+	if entry.Size > maxSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"File Too Large",
+			fmt.Sprintf("The file %s of %s is %d bytes, larger than max_size of %d bytes.", filePath, repoID, entry.Size, maxSize),
+		)
+		return
+	}
+
+	content, err := d.client.DownloadFileLimit(ctx, repoType, repoID, revision, filePath, maxSize)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("download file %s of %s", filePath, repoID), err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Downloaded %d bytes of %s from %s at %s", len(content), filePath, repoID, revision))
+
+	data.Content = types.StringNull()
+	if utf8.Valid(content) {
+		data.Content = types.StringValue(string(content))
+	}
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	data.Size = types.Int64Value(entry.Size)
+	data.LFS = types.BoolValue(entry.LFS != nil)
+	data.BlobSHA = types.StringValue(entry.OID)
+	data.LastCommitSHA = types.StringNull()
+	if entry.LastCommit != nil {
+		data.LastCommitSHA = types.StringValue(entry.LastCommit.ID)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewRepoFileDataSource() datasource.DataSource {
+	return &RepoFileDataSource{}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &RepoFilesDataSource{}
+
+// RepoFilesDataSource defines the data source implementation.
+type RepoFilesDataSource struct {
+	client *hfclient.Client
+}
+
+// RepoFilesDataSourceModel describes the data source data model.
+type RepoFilesDataSourceModel struct {
+	RepoID    types.String          `tfsdk:"repo_id"`
+	RepoType  types.String          `tfsdk:"repo_type"`
+	Revision  types.String          `tfsdk:"revision"`
+	Path      types.String          `tfsdk:"path"`
+	Recursive types.Bool            `tfsdk:"recursive"`
+	Paths     types.List            `tfsdk:"paths"`
+	Files     []RepoFilesEntryModel `tfsdk:"files"`
+}
+
+// RepoFilesEntryModel describes a file listed by the data source.
+type RepoFilesEntryModel struct {
+	Path            types.String `tfsdk:"path"`
+	Size            types.Int64  `tfsdk:"size"`
+	LFS             types.Bool   `tfsdk:"lfs"`
+	BlobSHA         types.String `tfsdk:"blob_sha"`
+	LastCommitSHA   types.String `tfsdk:"last_commit_sha"`
+	LastCommitTitle types.String `tfsdk:"last_commit_title"`
+	LastCommitDate  types.String `tfsdk:"last_commit_date"`
+}
+
+func (d *RepoFilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_files"
+}
+
+func (d *RepoFilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the files of a space, model or dataset repository at a revision.",
+		Attributes: map[string]schema.Attribute{
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit SHA to list the files of. Defaults to `main`.",
+				Optional:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The directory to list the files of. Defaults to the root of the repository.",
+				Optional:            true,
+			},
+			"recursive": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the files of all subdirectories as well. Defaults to `false`.",
+				Optional:            true,
+			},
+			"paths": schema.ListAttribute{
+				MarkdownDescription: "Paths of the files, relative to the root of the repository.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"files": schema.ListNestedAttribute{
+				MarkdownDescription: "The files. Directories are not listed.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							MarkdownDescription: "The path of the file, relative to the root of the repository.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The size of the file in bytes.",
+							Computed:            true,
+						},
+						"lfs": schema.BoolAttribute{
+							MarkdownDescription: "Whether the file is stored in Git LFS.",
+							Computed:            true,
+						},
+						"blob_sha": schema.StringAttribute{
+							MarkdownDescription: "The SHA of the Git blob of the file.",
+							Computed:            true,
+						},
+						"last_commit_sha": schema.StringAttribute{
+							MarkdownDescription: "The SHA of the last commit touching the file.",
+							Computed:            true,
+						},
+						"last_commit_title": schema.StringAttribute{
+							MarkdownDescription: "The title of the last commit touching the file.",
+							Computed:            true,
+						},
+						"last_commit_date": schema.StringAttribute{
+							MarkdownDescription: "When the last commit touching the file was made.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RepoFilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *RepoFilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RepoFilesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := hfclient.RepoTypeSpace
+	if !data.RepoType.IsNull() {
+		repoType = hfclient.RepoType(data.RepoType.ValueString())
+	}
+
+	revision := defaultBranch
+	if !data.Revision.IsNull() {
+		revision = data.Revision.ValueString()
+	}
+
+	entries, err := d.client.ListTree(ctx, repoType, data.RepoID.ValueString(), revision, hfclient.ListTreeRequest{
+		Path:      data.Path.ValueString(),
+		Recursive: data.Recursive.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list files of %s", data.RepoID.ValueString()), err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d tree entries of %s at %s", len(entries), data.RepoID.ValueString(), revision))
+
+	paths := make([]string, 0, len(entries))
+	data.Files = make([]RepoFilesEntryModel, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type != "file" {
+			continue
+		}
+
+		file := RepoFilesEntryModel{
+			Path:            types.StringValue(entry.Path),
+			Size:            types.Int64Value(entry.Size),
+			LFS:             types.BoolValue(entry.LFS != nil),
+			BlobSHA:         types.StringValue(entry.OID),
+			LastCommitSHA:   types.StringNull(),
+			LastCommitTitle: types.StringNull(),
+			LastCommitDate:  types.StringNull(),
+		}
+		if commit := entry.LastCommit; commit != nil {
+			file.LastCommitSHA = types.StringValue(commit.ID)
+			file.LastCommitTitle = types.StringValue(commit.Title)
+			file.LastCommitDate = types.StringValue(commit.Date)
+		}
+
+		paths = append(paths, entry.Path)
+		data.Files = append(data.Files, file)
+	}
+
+	pathsValue, diags := stringListValue(ctx, paths)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Paths = pathsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewRepoFilesDataSource() datasource.DataSource {
+	return &RepoFilesDataSource{}
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// repoRenameTarget returns the ID of the repository repoID once it is renamed
+// to name. Repositories keep their namespace when renamed.
+func repoRenameTarget(repoID string, name string) string {
+	namespace, _ := splitRepoID(repoID)
+	return namespace + "/" + name
+}
+
+// planRepoRename plans the ID a model or dataset is renamed to when its name
+// changes.
+func planRepoRename(ctx context.Context, repoType hfclient.RepoType, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is renamed on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var repoID, name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &repoID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if name.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		return
+	}
+
+	toRepo := repoRenameTarget(repoID.ValueString(), name.ValueString())
+	if toRepo == repoID.ValueString() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), toRepo)...)
+	resp.Diagnostics.AddWarning(
+		"Repository Will Be Renamed",
+		fmt.Sprintf("The %s %s will be renamed to %s. Links to the old URL only keep working as long as the Hub redirects them.", repoType, repoID.ValueString(), toRepo),
+	)
+}
+
+// renameRepo renames the repository fromRepo to toRepo, which keeps its
+// contents and settings.
+func renameRepo(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, fromRepo string, toRepo string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, fmt.Sprintf("Renaming %s %s to %s", repoType, fromRepo, toRepo))
+
+	err := client.MoveRepo(ctx, hfclient.MoveRepoRequest{
+		FromRepo: fromRepo,
+		ToRepo:   toRepo,
+		Type:     repoType,
+	})
+	switch code := hfclient.StatusCode(err); {
+	case err == nil:
+	case code == http.StatusForbidden || code == http.StatusConflict:
+		// The target name must be free.
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Repository Rename Rejected",
+			fmt.Sprintf("Unable to rename %s %s to %s, got %s", repoType, fromRepo, toRepo, err),
+		)
+	default:
+		addClientError(diags, fmt.Sprintf("rename %s %s to %s", repoType, fromRepo, toRepo), err)
+	}
+}
+
+// setRepoPrivate updates the visibility of the repository repoID.
+func setRepoPrivate(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, private bool, diags *diag.Diagnostics) {
+	err := client.UpdateRepoSettings(ctx, repoType, repoID, hfclient.RepoSettings{
+		Private: &private,
+	})
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("update %s visibility", repoType), err)
+	}
+}
+
+// writeRepoLicense sets the license in the card of a repository, committing
+// the card if it changed. A null license is not managed.
+func writeRepoLicense(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, license types.String, diags *diag.Diagnostics) {
+	if license.IsNull() || license.IsUnknown() {
+		return
+	}
+
+	card, err := loadRepoCard(ctx, client, repoType, repoID)
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("read %s card", repoType), err)
+		return
+	}
+
+	card.set("license", license.ValueString())
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating license of %s %s", repoType, repoID))
+
+	if err := card.save(ctx, client, repoType, repoID); err != nil {
+		addClientError(diags, fmt.Sprintf("update %s card", repoType), err)
+	}
+}
+
+// readRepoLicense refreshes the license from the card of a repository. Only
+// a managed license is read back.
+func readRepoLicense(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, license types.String, diags *diag.Diagnostics) types.String {
+	if license.IsNull() || license.IsUnknown() {
+		return license
+	}
+
+	card, err := loadRepoCard(ctx, client, repoType, repoID)
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("read %s card", repoType), err)
+		return license
+	}
+
+	value := card.get("license")
+	if value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(value.Value)
+}
+
+// importRepoLicense sets the license of the imported repository repoID to the
+// one in its card, if any, as only a managed license is read back.
+func importRepoLicense(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, resp *resource.ImportStateResponse) {
+	card, err := loadRepoCard(ctx, client, repoType, repoID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read %s card", repoType), err)
+		return
+	}
+
+	if value := card.get("license"); value != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("license"), value.Value)...)
+	}
+}
+
+// deleteRepo deletes the repository repoID. A repository that is already
+// gone is as good as deleted.
+func deleteRepo(ctx context.Context, client *hfclient.Client, repoType hfclient.RepoType, repoID string, diags *diag.Diagnostics) {
+	namespace, name := splitRepoID(repoID)
+	err := client.DeleteRepo(ctx, hfclient.DeleteRepoRequest{
+		Type:         repoType,
+		Name:         name,
+		Organization: namespace,
+	})
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(diags, fmt.Sprintf("delete %s", repoType), err)
+	}
+}
+
+// importRepoState imports a model or dataset by its ID, setting the name and
+// namespace it is read with.
+func importRepoState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, name := splitRepoID(req.ID)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	if namespace != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	}
+}
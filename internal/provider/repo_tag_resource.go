@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoTagResource{}
+	_ resource.ResourceWithConfigure   = &RepoTagResource{}
+	_ resource.ResourceWithImportState = &RepoTagResource{}
+)
+
+// RepoTagResource defines the resource implementation.
+type RepoTagResource struct {
+	client *hfclient.Client
+}
+
+// RepoTagResourceModel describes the resource data model.
+type RepoTagResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	RepoID    types.String `tfsdk:"repo_id"`
+	RepoType  types.String `tfsdk:"repo_type"`
+	Name      types.String `tfsdk:"name"`
+	Revision  types.String `tfsdk:"revision"`
+	Message   types.String `tfsdk:"message"`
+	CommitSHA types.String `tfsdk:"commit_sha"`
+}
+
+func (r *RepoTagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_tag"
+}
+
+func (r *RepoTagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a tag of a space, model or dataset repository, such as a release tag pinning a deployment to a commit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tag, in the form `repo_type:namespace/name@tag`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the repository, in the form `namespace/name`. Changing this forces a new tag to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the repository, one of `model`, `dataset` or `space`. Defaults to `space`. Changing this forces a new tag to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hfclient.RepoTypeSpace)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tag. Changing this forces a new tag to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit SHA to tag. Defaults to `main`. Changing this forces a new tag to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultBranch),
+				PlanModifiers: []planmodifier.String{
+					refRequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The message of the tag. Changing this forces a new tag to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					refRequiresReplace(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the commit the tag points at.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoTagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RepoTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := hfclient.RepoType(data.RepoType.ValueString())
+
+	tflog.Debug(ctx, fmt.Sprintf("Tagging revision %s of %s as %s", data.Revision.ValueString(), data.RepoID.ValueString(), data.Name.ValueString()))
+
+	err := r.client.CreateTag(ctx, repoType, data.RepoID.ValueString(), data.Name.ValueString(), data.Revision.ValueString(), data.Message.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("create tag %s", data.Name.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), data.Name.ValueString()))
+
+	// The revision may be a branch or tag, so the commit it resolves to is
+	// read back.
+	ref, found := readGitRef(ctx, r.client, repoType, data.RepoID.ValueString(), "tag", data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Tag %s was not found after it was created", data.ID.ValueString()))
+		return
+	}
+	data.CommitSHA = types.StringValue(ref.TargetCommit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ref, found := readGitRef(ctx, r.client, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), "tag", data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("Tag %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.CommitSHA = types.StringValue(ref.TargetCommit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every configurable attribute forces a new tag to be created, except
+	// the revision and message of an imported tag, which are only recorded.
+	var state RepoTagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CommitSHA = state.CommitSHA
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, refImportedPrivateKey, nil)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A tag that is already gone, or whose repository is, is as good as
+	// deleted.
+	err := r.client.DeleteTag(ctx, hfclient.RepoType(data.RepoType.ValueString()), data.RepoID.ValueString(), data.Name.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete tag %s", data.Name.ValueString()), err)
+		return
+	}
+}
+
+func (r *RepoTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRepoRef(ctx, req, resp)
+}
+
+func NewRepoTagResource() resource.Resource {
+	return &RepoTagResource{}
+}
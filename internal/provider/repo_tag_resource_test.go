@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRepoTagType is the type name of the repo tag resource.
+const testRepoTagType = "huggingface-spaces_repo_tag"
+
+func TestRepoTagResourceImport(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodGet, "/api/spaces/testuser/demo/refs", http.StatusOK,
+		`{"branches": [{"name": "main", "targetCommit": "aaaa"}], "tags": [{"name": "v1", "targetCommit": "bbbb"}]}`)
+
+	tag := newMockProvider(t, api).resource(testRepoTagType)
+	requireNoErrors(t, "import", tag.importState("space:testuser/demo@v1"))
+	if sha := tag.stringAttribute("commit_sha"); sha != "bbbb" {
+		t.Errorf("commit_sha = %q, expected bbbb", sha)
+	}
+
+	// The revision and message of a tag cannot be read back, so importing
+	// it records the configured ones instead of tagging again.
+	config := map[string]attr.Value{
+		"repo_id": types.StringValue("testuser/demo"),
+		"name":    types.StringValue("v1"),
+		"message": types.StringValue("First release"),
+	}
+	_, requiresReplace, diags := tag.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Fatalf("importing requires replacing %v", requiresReplace)
+	}
+	requireNoErrors(t, "apply", tag.apply(config))
+
+	if requests := api.requestsTo(http.MethodPost, ""); len(requests) != 0 {
+		t.Errorf("sent %d requests to tag again, expected none", len(requests))
+	}
+	if revision := tag.stringAttribute("revision"); revision != "main" {
+		t.Errorf("revision = %q, expected main", revision)
+	}
+	if !tag.planIsEmpty(config) {
+		t.Error("plan is not empty after import")
+	}
+
+	// Once recorded, changing the message tags again.
+	config["message"] = types.StringValue("Second release")
+	_, requiresReplace, diags = tag.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 1 || !requiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("message")) {
+		t.Errorf("changing the message requires replacing %v, expected message", requiresReplace)
+	}
+}
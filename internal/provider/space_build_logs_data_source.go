@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// maxBuildLogsBytes caps how much of the build logs stream is read into state.
+const maxBuildLogsBytes = 1 << 20
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpaceBuildLogsDataSource{}
+
+// SpaceBuildLogsDataSource defines the data source implementation.
+type SpaceBuildLogsDataSource struct {
+	client *hfclient.Client
+}
+
+// SpaceBuildLogsDataSourceModel describes the data source data model.
+type SpaceBuildLogsDataSourceModel struct {
+	SpaceID types.String `tfsdk:"space_id"`
+	Logs    types.String `tfsdk:"logs"`
+}
+
+func (d *SpaceBuildLogsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_build_logs"
+}
+
+func (d *SpaceBuildLogsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `owner/name`.",
+				Required:            true,
+			},
+			"logs": schema.StringAttribute{
+				MarkdownDescription: "The build logs of the space, truncated to 1 MiB.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SpaceBuildLogsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SpaceBuildLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpaceBuildLogsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read one byte past the cap to tell whether the logs were truncated.
+	logs, err := d.client.GetSpaceBuildLogs(ctx, data.SpaceID.ValueString(), maxBuildLogsBytes+1)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read space build logs", err)
+		return
+	}
+
+	if len(logs) > maxBuildLogsBytes {
+		logs = logs[:maxBuildLogsBytes]
+		resp.Diagnostics.AddWarning(
+			"Build Logs Truncated",
+			fmt.Sprintf("The build logs of space %s exceed %d bytes and have been truncated.", data.SpaceID.ValueString(), maxBuildLogsBytes),
+		)
+	}
+
+	data.Logs = types.StringValue(string(logs))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpaceBuildLogsDataSource() datasource.DataSource {
+	return &SpaceBuildLogsDataSource{}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// serveBuildLogs makes api stream chunks as the build logs of the space
+// spaceID, flushing each of them separately.
+func serveBuildLogs(api *mockAPI, spaceID string, chunks ...string) {
+	api.handleFunc(http.MethodGet, "/api/spaces/"+spaceID+"/logs/build", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			_, _ = w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+	})
+}
+
+func TestSpaceBuildLogsDataSource(t *testing.T) {
+	api := newMockAPI(t)
+	serveBuildLogs(api, "testuser/broken",
+		"Step 1/3 : FROM python:3.10\n",
+		"Step 2/3 : RUN pip install -r requirements.txt\nERROR: No matching distribution found for torch==9.9\n",
+		"Step 3/3 : failed\n",
+	)
+
+	state, diags := newMockProvider(t, api).readDataSource("huggingface-spaces_space_build_logs", map[string]attr.Value{
+		"space_id": types.StringValue("testuser/broken"),
+	})
+	requireNoErrors(t, "read", diags)
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+
+	var logs string
+	if err := attributeValue(t, state, "logs").As(&logs); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Step 1/3 : FROM python:3.10\n" +
+		"Step 2/3 : RUN pip install -r requirements.txt\n" +
+		"ERROR: No matching distribution found for torch==9.9\n" +
+		"Step 3/3 : failed\n"
+	if logs != expected {
+		t.Errorf("logs = %q, expected %q", logs, expected)
+	}
+}
+
+func TestSpaceBuildLogsDataSourceTruncated(t *testing.T) {
+	api := newMockAPI(t)
+	line := strings.Repeat("x", 1023) + "\n"
+	serveBuildLogs(api, "testuser/verbose", strings.Repeat(line, maxBuildLogsBytes/len(line)), line)
+
+	state, diags := newMockProvider(t, api).readDataSource("huggingface-spaces_space_build_logs", map[string]attr.Value{
+		"space_id": types.StringValue("testuser/verbose"),
+	})
+	requireNoErrors(t, "read", diags)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, "Build Logs Truncated") == nil {
+		t.Errorf("read did not warn that the logs were truncated, got %v", diags)
+	}
+
+	var logs string
+	if err := attributeValue(t, state, "logs").As(&logs); err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != maxBuildLogsBytes {
+		t.Errorf("read %d bytes of logs, expected %d", len(logs), maxBuildLogsBytes)
+	}
+}
@@ -2,14 +2,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -17,7 +17,7 @@ var _ datasource.DataSource = &SpaceDataSource{}
 
 // SpaceDataSource defines the data source implementation.
 type SpaceDataSource struct {
-	client *http.Client
+	client *hfclient.Client
 }
 
 // SpaceDataSourceModel describes the data source data model.
@@ -32,6 +32,13 @@ type SpaceDataSourceModel struct {
 	Hardware     types.String `tfsdk:"hardware"`
 	Storage      types.String `tfsdk:"storage"`
 	SleepTime    types.Int64  `tfsdk:"sleep_time"`
+	Stage        types.String `tfsdk:"stage"`
+	Subdomain    types.String `tfsdk:"subdomain"`
+	URL          types.String `tfsdk:"url"`
+	SHA          types.String `tfsdk:"sha"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Disabled     types.Bool   `tfsdk:"disabled"`
+	Tags         types.List   `tfsdk:"tags"`
 }
 
 func (d *SpaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +47,7 @@ func (d *SpaceDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 
 func (d *SpaceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing space on the Hugging Face Hub without managing it.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Required: true,
@@ -71,6 +79,35 @@ func (d *SpaceDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			"sleep_time": schema.Int64Attribute{
 				Computed: true,
 			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "The stage of the space runtime, such as `BUILDING`, `RUNNING` or `SLEEPING`.",
+				Computed:            true,
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "The subdomain the space is served from below `hf.space`.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL the space is served from, such as `https://namespace-name.hf.space`.",
+				Computed:            true,
+			},
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the latest commit of the space repository.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the space was created.",
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the space was disabled by the Hub.",
+				Computed:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Tags of the space.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -81,18 +118,18 @@ func (d *SpaceDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *SpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -105,95 +142,58 @@ func (d *SpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s", data.ID.ValueString())
-	log.Printf("[DEBUG] Requesting URL: %s", url)
-
-	httpResp, err := d.client.Get(url)
+	space, err := d.client.GetSpace(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read space", err)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	log.Printf("[DEBUG] Response Status Code: %d", httpResp.StatusCode)
+	// Log the space response for debugging
+	tflog.Debug(ctx, fmt.Sprintf("Space Response: %+v", space))
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unexpected status code: %d", httpResp.StatusCode))
+	if space.ID == nil {
+		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'id' field is missing or not a string in the space response")
 		return
 	}
+	data.Name = types.StringValue(*space.ID)
 
-	var space map[string]interface{}
-	err = json.NewDecoder(httpResp.Body).Decode(&space)
-	if err != nil {
-		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode space JSON response, got error: %s", err))
-		return
-	}
+	data.Author = types.StringPointerValue(space.Author)
+	data.LastModified = types.StringPointerValue(space.LastModified)
+	data.Likes = types.Int64PointerValue(space.Likes)
+	data.Private = types.BoolPointerValue(space.Private)
+	data.SDK = types.StringPointerValue(space.SDK)
+	data.Subdomain = types.StringPointerValue(space.Subdomain)
+	data.SHA = types.StringPointerValue(space.SHA)
+	data.CreatedAt = types.StringPointerValue(space.CreatedAt)
+	data.Disabled = types.BoolValue(space.Disabled != nil && *space.Disabled)
 
-	// Log the space JSON response for debugging
-	log.Printf("[DEBUG] Space JSON Response: %+v", space)
-
-	if id, ok := space["id"].(string); ok {
-		data.Name = types.StringValue(id)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'id' field is missing or not a string in the space JSON response")
-		return
+	data.URL = types.StringNull()
+	if url := spaceURL(space); url != "" {
+		data.URL = types.StringValue(url)
 	}
 
-	if author, ok := space["author"].(string); ok {
-		data.Author = types.StringValue(author)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'author' field is missing or not a string in the space JSON response")
-		return
-	}
-
-	if lastModified, ok := space["lastModified"].(string); ok {
-		data.LastModified = types.StringValue(lastModified)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'lastModified' field is missing or not a string in the space JSON response")
-		return
-	}
-
-	if likes, ok := space["likes"].(float64); ok {
-		data.Likes = types.Int64Value(int64(likes))
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'likes' field is missing or not a number in the space JSON response")
-		return
-	}
-
-	if private, ok := space["private"].(bool); ok {
-		data.Private = types.BoolValue(private)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'private' field is missing or not a boolean in the space JSON response")
-		return
-	}
-
-	if sdk, ok := space["sdk"].(string); ok {
-		data.SDK = types.StringValue(sdk)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'sdk' field is missing or not a string in the space JSON response")
-		return
-	}
-
-	// Extract hardware, storage, and sleep time from the space JSON response
-	if hardware, ok := space["hardware"].(string); ok {
-		data.Hardware = types.StringValue(hardware)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'hardware' field is missing or not a string in the space JSON response")
-		return
-	}
-
-	if storage, ok := space["storage"].(string); ok {
-		data.Storage = types.StringValue(storage)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'storage' field is missing or not a string in the space JSON response")
+	tags, diags := stringListValue(ctx, space.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if sleepTime, ok := space["sleepTime"].(float64); ok {
-		data.SleepTime = types.Int64Value(int64(sleepTime))
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'sleepTime' field is missing or not a number in the space JSON response")
-		return
+	data.Tags = tags
+
+	// Hardware, storage, sleep time and stage are only known for spaces that have
+	// a runtime.
+	data.Hardware = types.StringNull()
+	data.Storage = types.StringNull()
+	data.SleepTime = types.Int64Null()
+	data.Stage = types.StringNull()
+	if space.Runtime != nil {
+		data.Stage = types.StringValue(space.Runtime.Stage)
+		data.Hardware = types.StringPointerValue(space.Runtime.Hardware.Current)
+		data.Storage = types.StringPointerValue(space.Runtime.Storage.Current)
+		if space.Runtime.SleepTime != nil {
+			data.SleepTime = types.Int64Value(*space.Runtime.SleepTime)
+		} else {
+			data.SleepTime = types.Int64Value(sleepTimeNever)
+		}
 	}
 
 	// Save data into Terraform state
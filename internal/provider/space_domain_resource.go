@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// customDomainRecordType is the type of the DNS record pointing a custom
+// domain at a space.
+const customDomainRecordType = "CNAME"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceDomainResource{}
+	_ resource.ResourceWithConfigure   = &SpaceDomainResource{}
+	_ resource.ResourceWithImportState = &SpaceDomainResource{}
+)
+
+// SpaceDomainResource defines the resource implementation.
+type SpaceDomainResource struct {
+	client *hfclient.Client
+}
+
+// SpaceDomainResourceModel describes the resource data model.
+type SpaceDomainResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SpaceID       types.String `tfsdk:"space_id"`
+	Domain        types.String `tfsdk:"domain"`
+	DNSRecordType types.String `tfsdk:"dns_record_type"`
+	DNSTarget     types.String `tfsdk:"dns_target"`
+}
+
+func (r *SpaceDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_domain"
+}
+
+func (r *SpaceDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a custom domain to a space. Custom domains require the owner of the space to be on a paid plan. Spaces whose domain is managed by this resource should leave `custom_domain` unset and ignore changes to it with `lifecycle { ignore_changes = [custom_domain] }`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`. Changing this moves the domain to another space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The custom domain to serve the space from, such as `demo.example.com`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hostnameRegexp, "must be a valid hostname"),
+				},
+			},
+			"dns_record_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the DNS record to create for `domain`, always `" + customDomainRecordType + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_target": schema.StringAttribute{
+				MarkdownDescription: "The host the DNS record of `domain` must point to for the Hub to validate the domain and serve the space from it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SpaceDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SpaceDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Attaching custom domain %s to space %s", data.Domain.ValueString(), data.SpaceID.ValueString()))
+
+	setSpaceCustomDomain(ctx, r.client, data.SpaceID.ValueString(), data.Domain.ValueString(), path.Root("domain"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.SpaceID
+	r.readDNSTarget(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	space, err := r.client.GetSpace(ctx, data.SpaceID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s not found, removing custom domain from state", data.SpaceID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read space", err)
+		return
+	}
+
+	if space.CustomDomain == nil || *space.CustomDomain == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s has no custom domain, removing it from state", data.SpaceID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Domain = types.StringValue(*space.CustomDomain)
+	data.DNSRecordType = types.StringValue(customDomainRecordType)
+	data.DNSTarget = types.StringPointerValue(spaceDNSTarget(space))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Changing custom domain of space %s to %s", data.SpaceID.ValueString(), data.Domain.ValueString()))
+
+	setSpaceCustomDomain(ctx, r.client, data.SpaceID.ValueString(), data.Domain.ValueString(), path.Root("domain"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readDNSTarget(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Detaching custom domain %s from space %s", data.Domain.ValueString(), data.SpaceID.ValueString()))
+
+	// A domain whose space is already gone is as good as detached.
+	err := r.client.UpdateRepoSettings(ctx, hfclient.RepoTypeSpace, data.SpaceID.ValueString(), hfclient.RepoSettings{
+		CustomDomain: new(string),
+	})
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "detach space custom domain", err)
+		return
+	}
+}
+
+func (r *SpaceDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !repoIDRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), req.ID)...)
+}
+
+// readDNSTarget refreshes the DNS record the custom domain must point to.
+func (r *SpaceDomainResource) readDNSTarget(ctx context.Context, data *SpaceDomainResourceModel, diags *diag.Diagnostics) {
+	space, err := r.client.GetSpace(ctx, data.SpaceID.ValueString())
+	if err != nil {
+		addClientError(diags, "read space", err)
+		return
+	}
+
+	data.DNSRecordType = types.StringValue(customDomainRecordType)
+	data.DNSTarget = types.StringPointerValue(spaceDNSTarget(space))
+}
+
+// spaceDNSTarget returns the host custom domains of a space must point to,
+// which is the hf.space subdomain the space is served from.
+func spaceDNSTarget(space *hfclient.Space) *string {
+	if space.Subdomain == nil || *space.Subdomain == "" {
+		return nil
+	}
+
+	target := *space.Subdomain + ".hf.space"
+	return &target
+}
+
+func NewSpaceDomainResource() resource.Resource {
+	return &SpaceDomainResource{}
+}
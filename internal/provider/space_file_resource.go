@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// defaultBranch is the branch files are committed to unless configured
+// otherwise.
+const defaultBranch = "main"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceFileResource{}
+	_ resource.ResourceWithConfigure   = &SpaceFileResource{}
+	_ resource.ResourceWithImportState = &SpaceFileResource{}
+)
+
+// SpaceFileResource defines the resource implementation.
+type SpaceFileResource struct {
+	client *hfclient.Client
+}
+
+// SpaceFileResourceModel describes the resource data model.
+type SpaceFileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SpaceID       types.String `tfsdk:"space_id"`
+	Path          types.String `tfsdk:"path"`
+	Content       types.String `tfsdk:"content"`
+	Branch        types.String `tfsdk:"branch"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+	CommitSHA     types.String `tfsdk:"commit_sha"`
+}
+
+func (r *SpaceFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_file"
+}
+
+func (r *SpaceFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single file in the repository of a space. Every change is committed separately and rebuilds the space.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the file, in the form `namespace/name/path`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`. Changing this forces a new file to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the file in the repository, such as `app.py`. Changing this forces a new file to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the file, given inline or read with `file()`.",
+				Required:            true,
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "The branch to commit the file to. Defaults to `main`. Changing this forces a new file to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultBranch),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				MarkdownDescription: "The message of the commits made to the file. Defaults to a message naming the file.",
+				Optional:            true,
+			},
+			"content_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 of the content of the file in the repository, used to detect changes made outside of Terraform.",
+				Computed:            true,
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the commit that last wrote the file.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SpaceFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SpaceFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.commitFile(ctx, data, "Add", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.SpaceID.ValueString() + "/" + data.Path.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := r.client.DownloadFile(ctx, hfclient.RepoTypeSpace, data.SpaceID.ValueString(), data.Branch.ValueString(), data.Path.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("File %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read file %s", data.Path.ValueString()), err)
+		return
+	}
+
+	// The content is only refreshed when it changed, so that the exact
+	// configured value is kept otherwise.
+	sha := contentSHA256(content)
+	if sha != data.ContentSHA256.ValueString() {
+		tflog.Debug(ctx, fmt.Sprintf("File %s changed outside of Terraform", data.ID.ValueString()))
+		data.Content = types.StringValue(string(content))
+		data.ContentSHA256 = types.StringValue(sha)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SpaceFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the commit message changed, so there is nothing to commit.
+	if data.Content.Equal(state.Content) {
+		data.ContentSHA256 = state.ContentSHA256
+		data.CommitSHA = state.CommitSHA
+	} else {
+		r.commitFile(ctx, data, "Update", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.CreateCommit(ctx, hfclient.RepoTypeSpace, data.SpaceID.ValueString(), data.Branch.ValueString(), hfclient.CreateCommitRequest{
+		Summary: fmt.Sprintf("Delete %s", data.Path.ValueString()),
+		Operations: []hfclient.CommitOperation{
+			{Path: data.Path.ValueString(), Delete: true},
+		},
+	})
+
+	// A file whose space is already gone is as good as deleted.
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete file %s", data.Path.ValueString()), err)
+		return
+	}
+}
+
+func (r *SpaceFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name/path, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), parts[0]+"/"+parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), defaultBranch)...)
+}
+
+// commitFile commits the content of the file, recording the resulting
+// commit in data. verb describes the change in the default commit message.
+func (r *SpaceFileResource) commitFile(ctx context.Context, data *SpaceFileResourceModel, verb string, diags *diag.Diagnostics) {
+	summary := fmt.Sprintf("%s %s", verb, data.Path.ValueString())
+	if !data.CommitMessage.IsNull() {
+		summary = data.CommitMessage.ValueString()
+	}
+
+	content := []byte(data.Content.ValueString())
+
+	tflog.Debug(ctx, fmt.Sprintf("Committing file %s to space %s", data.Path.ValueString(), data.SpaceID.ValueString()))
+
+	commit, err := r.client.CreateCommit(ctx, hfclient.RepoTypeSpace, data.SpaceID.ValueString(), data.Branch.ValueString(), hfclient.CreateCommitRequest{
+		Summary: summary,
+		Operations: []hfclient.CommitOperation{
+			{Path: data.Path.ValueString(), Content: content},
+		},
+	})
+	if err != nil {
+		addClientError(diags, fmt.Sprintf("commit file %s", data.Path.ValueString()), err)
+		return
+	}
+
+	data.ContentSHA256 = types.StringValue(contentSHA256(content))
+	data.CommitSHA = types.StringValue(commit.CommitOID)
+}
+
+// contentSHA256 returns the hex encoded SHA-256 of content.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func NewSpaceFileResource() resource.Resource {
+	return &SpaceFileResource{}
+}
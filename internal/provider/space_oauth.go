@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// oauthScopes lists the scopes a space can request from users signing in
+// with Hugging Face. The openid and profile scopes are always granted.
+var oauthScopes = []string{"openid", "profile", "email", "read-repos", "write-repos", "manage-repos", "inference-api", "read-billing"}
+
+// SpaceOAuthModel describes the oauth block.
+type SpaceOAuthModel struct {
+	Scopes            types.List  `tfsdk:"scopes"`
+	ExpirationMinutes types.Int64 `tfsdk:"expiration_minutes"`
+}
+
+// oauthAttrTypes are the attribute types of the oauth object.
+var oauthAttrTypes = map[string]attr.Type{
+	"scopes":             types.ListType{ElemType: types.StringType},
+	"expiration_minutes": types.Int64Type,
+}
+
+// spaceOAuthBlock returns the oauth block of the space resource.
+func spaceOAuthBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Turns the space into an OAuth app so that users can sign in with Hugging Face, by setting `hf_oauth` in the YAML frontmatter of its `README.md`. Removing the block disables it.",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "The scopes the space requests from users signing in, among `" + strings.Join(oauthScopes, "`, `") + "`. Defaults to `openid` and `profile`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(oauthScopes...)),
+				},
+			},
+			"expiration_minutes": schema.Int64Attribute{
+				MarkdownDescription: "How long the access tokens issued to users signing in are valid for, in minutes. Defaults to 480, or 8 hours.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(30, 43200),
+				},
+			},
+		},
+	}
+}
+
+// spaceOAuth returns the oauth block, or nil if it is not set.
+func spaceOAuth(ctx context.Context, block types.Object, diags *diag.Diagnostics) *SpaceOAuthModel {
+	if block.IsNull() || block.IsUnknown() {
+		return nil
+	}
+
+	var oauth SpaceOAuthModel
+	diags.Append(block.As(ctx, &oauth, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &oauth
+}
+
+// writeCardOAuth enables OAuth in card with the attributes of oauth that are
+// set.
+func writeCardOAuth(ctx context.Context, card *repoCard, oauth *SpaceOAuthModel, diags *diag.Diagnostics) {
+	card.set("hf_oauth", true)
+
+	if !oauth.Scopes.IsNull() {
+		var scopes []string
+		diags.Append(oauth.Scopes.ElementsAs(ctx, &scopes, false)...)
+		card.set("hf_oauth_scopes", scopes)
+	}
+	if !oauth.ExpirationMinutes.IsNull() {
+		card.set("hf_oauth_expiration_minutes", oauth.ExpirationMinutes.ValueInt64())
+	}
+}
+
+// readCardOAuth refreshes the attributes of oauth that are set from card, and
+// reports whether OAuth is still enabled.
+func readCardOAuth(ctx context.Context, card *repoCard, oauth *SpaceOAuthModel, diags *diag.Diagnostics) bool {
+	var enabled bool
+	if node := card.get("hf_oauth"); node == nil || node.Decode(&enabled) != nil || !enabled {
+		return false
+	}
+
+	if !oauth.Scopes.IsNull() {
+		oauth.Scopes = types.ListNull(types.StringType)
+
+		var scopes []string
+		if node := card.get("hf_oauth_scopes"); node != nil && node.Decode(&scopes) == nil {
+			value, d := types.ListValueFrom(ctx, types.StringType, scopes)
+			diags.Append(d...)
+			oauth.Scopes = value
+		}
+	}
+
+	if !oauth.ExpirationMinutes.IsNull() {
+		oauth.ExpirationMinutes = types.Int64Null()
+
+		var minutes int64
+		if node := card.get("hf_oauth_expiration_minutes"); node != nil && node.Decode(&minutes) == nil {
+			oauth.ExpirationMinutes = types.Int64Value(minutes)
+		}
+	}
+
+	return true
+}
+
+// disableSpaceOAuth turns off OAuth in the card of a space whose oauth block
+// was removed, committing the card if it changed.
+func disableSpaceOAuth(ctx context.Context, client *hfclient.Client, spaceID string, diags *diag.Diagnostics) {
+	card, err := loadRepoCard(ctx, client, hfclient.RepoTypeSpace, spaceID)
+	if err != nil {
+		addClientError(diags, "read space card", err)
+		return
+	}
+
+	if card.get("hf_oauth") == nil {
+		return
+	}
+	card.set("hf_oauth", false)
+
+	tflog.Debug(ctx, fmt.Sprintf("Disabling OAuth of space %s", spaceID))
+
+	if err := card.save(ctx, client, hfclient.RepoTypeSpace, spaceID); err != nil {
+		addClientError(diags, "update space card", err)
+	}
+}
@@ -4,44 +4,226 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &SpaceResource{}
-	_ resource.ResourceWithConfigure   = &SpaceResource{}
-	_ resource.ResourceWithImportState = &SpaceResource{}
+	_ resource.Resource                     = &SpaceResource{}
+	_ resource.ResourceWithConfigure        = &SpaceResource{}
+	_ resource.ResourceWithImportState      = &SpaceResource{}
+	_ resource.ResourceWithValidateConfig   = &SpaceResource{}
+	_ resource.ResourceWithConfigValidators = &SpaceResource{}
+	_ resource.ResourceWithModifyPlan       = &SpaceResource{}
+	_ resource.ResourceWithUpgradeState     = &SpaceResource{}
+)
+
+const (
+	// defaultCreateTimeout bounds Create unless timeouts.create is set.
+	defaultCreateTimeout = 20 * time.Minute
+
+	// defaultUpdateTimeout bounds Update unless timeouts.update is set.
+	defaultUpdateTimeout = 20 * time.Minute
+
+	// defaultDeleteTimeout bounds Delete, including the wait for a deleted
+	// space to disappear, unless timeouts.delete is set.
+	defaultDeleteTimeout = 5 * time.Minute
+
+	// deletePollInterval is the time between two checks for a deleted space.
+	deletePollInterval = 2 * time.Second
+
+	// defaultWaitForStage is the stage wait_for waits for by default.
+	defaultWaitForStage = "RUNNING"
+
+	// defaultWaitForTimeout bounds how long wait_for waits by default.
+	defaultWaitForTimeout = 15 * time.Minute
+
+	// waitForPollInterval is the time between two checks of the stage of a
+	// space.
+	waitForPollInterval = 5 * time.Second
 )
 
+// waitForStages lists the stages wait_for can wait for.
+var waitForStages = []string{"RUNNING", "APP_STARTING", "SLEEPING", "PAUSED", "STOPPED"}
+
+// failedStages lists the stages of a space that failed to build or start, and
+// will not get anywhere without a change to the space.
+var failedStages = map[string]bool{
+	"NO_APP_FILE":   true,
+	"CONFIG_ERROR":  true,
+	"BUILD_ERROR":   true,
+	"RUNTIME_ERROR": true,
+}
+
+// gitURLRegexp matches Git repository URLs that can be imported into a space.
+var gitURLRegexp = regexp.MustCompile(`^(https://|git@)\S+$`)
+
+// spaceSDKs lists the SDKs a space can be created with.
+var spaceSDKs = []string{"gradio", "streamlit", "docker", "static"}
+
+// repoIDRegexp matches repository IDs of the form namespace/name.
+var repoIDRegexp = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// desiredStates lists the states desired_state can request.
+var desiredStates = []string{"running", "paused"}
+
+// devModeSSHHost is the host spaces in Dev Mode are connected to over SSH.
+const devModeSSHHost = "ssh.hf.space"
+
+// sleepTimeNever is the sleep time of a space that never goes to sleep.
+const sleepTimeNever = -1
+
+// hostnameRegexp matches fully qualified hostnames such as demo.example.com.
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}$`)
+
+// sleepTimeIgnoredHardware lists the free hardware flavors, which always use
+// the default sleep time of the Hub and reject a configured sleep_time.
+var sleepTimeIgnoredHardware = map[string]bool{
+	"cpu-basic": true,
+}
+
+// storageTiers lists the persistent storage tiers, from smallest to largest.
+var storageTiers = []string{"small", "medium", "large"}
+
+// spaceRegions lists the regions hardware can be requested in.
+var spaceRegions = []string{"us", "eu"}
+
+// hardwareRegions restricts hardware flavors that are only offered in some
+// regions. Flavors missing from this map are available in every region.
+var hardwareRegions = map[string][]string{
+	zeroGPUHardware: {"us"},
+	"h100":          {"us"},
+	"h100x8":        {"us"},
+}
+
+// secretsWOPrivateKey is the private state key listing the keys of the
+// write-only secrets last sent to the API, as their values are not stored.
+const secretsWOPrivateKey = "secrets_wo_keys"
+
 // SpaceResource defines the resource implementation.
 type SpaceResource struct {
-	client *http.Client
+	client *hfclient.Client
+
+	// maxConcurrentRequests bounds the secrets and variables written at once.
+	maxConcurrentRequests int
+
+	// defaults are the provider defaults of attributes spaces omit.
+	defaults spaceDefaults
+
+	// prices caches the prices of hardware flavors for cost estimates.
+	prices *hardwarePrices
 }
 
 // SpaceResourceModel describes the resource data model.
 type SpaceResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Private   types.Bool   `tfsdk:"private"`
-	SDK       types.String `tfsdk:"sdk"`
-	Template  types.String `tfsdk:"template"`
-	Secrets   types.Map    `tfsdk:"secrets"`
-	Variables types.Map    `tfsdk:"variables"`
-	Hardware  types.String `tfsdk:"hardware"`
-	Storage   types.String `tfsdk:"storage"`
-	SleepTime types.Int64  `tfsdk:"sleep_time"`
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Private    types.Bool   `tfsdk:"private"`
+	SDK        types.String `tfsdk:"sdk"`
+	SDKVersion types.String `tfsdk:"sdk_version"`
+	AppPort    types.Int64  `tfsdk:"app_port"`
+	Template   types.String `tfsdk:"template"`
+	FromGit    types.String `tfsdk:"from_git"`
+	Secrets    types.Map    `tfsdk:"secrets"`
+	Variables  types.Map    `tfsdk:"variables"`
+	Hardware   types.String `tfsdk:"hardware"`
+	Storage    types.String `tfsdk:"storage"`
+	SleepTime  types.Int64  `tfsdk:"sleep_time"`
+	Region     types.String `tfsdk:"region"`
+	Pinned     types.Bool   `tfsdk:"pinned"`
+	Tags       types.List   `tfsdk:"tags"`
+	Models     types.List   `tfsdk:"models"`
+	Datasets   types.List   `tfsdk:"datasets"`
+
+	DuplicateFrom types.String `tfsdk:"duplicate_from"`
+	CustomDomain  types.String `tfsdk:"custom_domain"`
+	CardMetadata  types.Object `tfsdk:"card_metadata"`
+	Gated         types.String `tfsdk:"gated"`
+	Gating        types.Object `tfsdk:"gating"`
+	DesiredState  types.String `tfsdk:"desired_state"`
+
+	DevMode           types.Bool   `tfsdk:"dev_mode"`
+	DevModeSSHHost    types.String `tfsdk:"dev_mode_ssh_host"`
+	DevModeSSHUser    types.String `tfsdk:"dev_mode_ssh_user"`
+	DevModeSSHCommand types.String `tfsdk:"dev_mode_ssh_command"`
+
+	SecretsWO        types.Map   `tfsdk:"secrets_wo"`
+	SecretsWOVersion types.Int64 `tfsdk:"secrets_wo_version"`
+
+	RestartTriggers types.Map  `tfsdk:"restart_triggers"`
+	FactoryReboot   types.Bool `tfsdk:"factory_reboot"`
+
+	StorageCurrent types.String `tfsdk:"storage_current"`
+	Subdomain      types.String `tfsdk:"subdomain"`
+	URL            types.String `tfsdk:"url"`
+	SHA            types.String `tfsdk:"sha"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	Disabled       types.Bool   `tfsdk:"disabled"`
+	Author         types.String `tfsdk:"author"`
+	Likes          types.Int64  `tfsdk:"likes"`
+	LastModified   types.String `tfsdk:"last_modified"`
+
+	EstimatedHourlyCost types.Float64 `tfsdk:"estimated_hourly_cost"`
+
+	CleanupSecrets           types.Bool `tfsdk:"cleanup_secrets"`
+	ManageSecretsExclusively types.Bool `tfsdk:"manage_secrets_exclusively"`
+	WaitForDeletion          types.Bool `tfsdk:"wait_for_deletion"`
+	AllowStorageDeletion     types.Bool `tfsdk:"allow_storage_deletion"`
+	DeletionProtection       types.Bool `tfsdk:"deletion_protection"`
+
+	Schedule          types.Object `tfsdk:"schedule"`
+	ScheduledHardware types.String `tfsdk:"scheduled_hardware"`
+
+	OAuth         types.Object `tfsdk:"oauth"`
+	OAuthClientID types.String `tfsdk:"oauth_client_id"`
+
+	Docker types.Object `tfsdk:"docker"`
+	Static types.Object `tfsdk:"static"`
+
+	WaitFor  types.Object   `tfsdk:"wait_for"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// SpaceWaitForModel describes the wait_for block.
+type SpaceWaitForModel struct {
+	Stage   types.String `tfsdk:"stage"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// stringListValue converts values to a list, treating a missing list in an API
+// response as an empty one.
+func stringListValue(ctx context.Context, values []string) (types.List, diag.Diagnostics) {
+	if values == nil {
+		values = []string{}
+	}
+	return types.ListValueFrom(ctx, types.StringType, values)
 }
 
 func (r *SpaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +232,7 @@ func (r *SpaceResource) Metadata(ctx context.Context, req resource.MetadataReque
 
 func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: spaceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -60,503 +243,2465 @@ func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Required: true,
 			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The user or organization the space belongs to. Defaults to the owner of the token. Changing this moves the space to the new namespace.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"private": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"sdk": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "The SDK the space runs with, one of `" + strings.Join(spaceSDKs, "`, `") + "`. Defaults to the SDK of the template, if any. Changing this forces a new space to be created.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(spaceSDKs...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sdk_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the Gradio or Streamlit SDK the space runs with, written into the `README.md` of the space.",
+				Optional:            true,
+			},
+			"app_port": schema.Int64Attribute{
+				MarkdownDescription: "The port a Docker space serves its app on, written into the `README.md` of the space. Deprecated, use `app_port` in the `docker` block instead.",
+				DeprecationMessage:  "Use app_port in the docker block instead.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
 			},
 			"template": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "The ID of a template space the space is created from, in the form `namespace/name`. The template is not returned by the API, so imported spaces have none. Changing this forces a new space to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					// Spaces created without a template have none in state,
+					// which is planned as unknown whenever anything else
+					// changes and must not replace them.
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"from_git": schema.StringAttribute{
+				MarkdownDescription: "URL of a Git repository whose contents are imported into the space once it has been created. Changing this forces a new space to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(gitURLRegexp, "must be an https:// or git@ Git repository URL"),
+					stringvalidator.ConflictsWith(path.MatchRoot("template")),
+				},
+			},
+			"duplicate_from": schema.StringAttribute{
+				MarkdownDescription: "ID of a space, in the form `namespace/name`, whose files, variables and settings are copied into the space when it is created. Secrets are not copied, and `secrets`, `variables`, `hardware`, `storage`, `sleep_time` and `private` override the copied settings. Changing this forces a new space to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(repoIDRegexp, "must be a space ID of the form namespace/name"),
+					stringvalidator.ConflictsWith(path.MatchRoot("template"), path.MatchRoot("from_git")),
+				},
 			},
 			"secrets": schema.MapAttribute{
-				Optional:    true,
-				ElementType: types.StringType,
+				MarkdownDescription: "Secrets of the space. Values are sensitive and never logged, only their keys are. The API never returns secret values, so only secrets added or deleted outside of Terraform are detected, not changed values.",
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"secrets_wo": schema.MapAttribute{
+				MarkdownDescription: "Secrets of the space that are sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. As Terraform cannot detect changes to write-only values, they are only sent when the space is created, when a key is added, or when `secrets_wo_version` changes.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"secrets_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version of the values of `secrets_wo`. Change it whenever those values change so that they are sent to the API again.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secrets_wo")),
+				},
 			},
 			"variables": schema.MapAttribute{
-				Optional:    true,
-				ElementType: types.StringType,
+				MarkdownDescription: "Variables of the space. Once set, they are managed exclusively: variables added, deleted or changed outside of Terraform show up in the next plan.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
 			"hardware": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "The hardware flavor the space runs on, such as `cpu-upgrade` or `t4-small`. ZeroGPU hardware, `zero-a10g`, is only available to Gradio spaces owned by PRO users or Team and Enterprise organizations. Defaults to the `default_hardware` of the provider, if set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					hardwareValidator{},
+				},
 			},
 			"storage": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "The persistent storage tier of the space, one of `" + strings.Join(storageTiers, "`, `") + "`. Removing it or moving to a smaller tier deletes the persistent storage and all its data, which requires `allow_storage_deletion`. Defaults to the `default_storage` of the provider, if set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(storageTiers...),
+				},
+			},
+			"storage_current": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier the space currently has, which lags behind `storage` while a storage change is in progress.",
+				Computed:            true,
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "The subdomain the space is served from below `hf.space`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL the space is served from, such as `https://namespace-name.hf.space`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The SHA of the latest commit of the space repository.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the space was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the space was disabled by the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"author": schema.StringAttribute{
+				MarkdownDescription: "The user or organization owning the space.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"likes": schema.Int64Attribute{
+				MarkdownDescription: "The number of likes of the space.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "When the space repository was last modified.",
+				Computed:            true,
+			},
+			"estimated_hourly_cost": schema.Float64Attribute{
+				MarkdownDescription: "The estimated price in USD per hour of the hardware the space is requested on and of its persistent storage, based on the prices of the Hub. Plans warn when it changes. Null when the price of the hardware is not known.",
+				Computed:            true,
 			},
 			"sleep_time": schema.Int64Attribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "Seconds of inactivity after which the space goes to sleep, or `-1` for a space that never sleeps. Cannot be set on free `cpu-basic` hardware, which always uses the sleep time of the Hub. Defaults to the `default_sleep_time` of the provider, if set, unless the space runs on free hardware.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The region the space hardware is requested in, one of `us` or `eu`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(spaceRegions...),
+				},
+			},
+			"pinned": schema.BoolAttribute{
+				MarkdownDescription: "Whether the space is pinned on the profile of its owner. Only spaces you own can be pinned.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"desired_state": schema.StringAttribute{
+				MarkdownDescription: "Whether the space should be `running` or `paused`. Paused spaces do not run on, nor are billed for, their hardware until they are set back to `running`, which restarts them.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(desiredStates...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dev_mode": schema.BoolAttribute{
+				MarkdownDescription: "Whether Dev Mode is enabled, which lets the owner of the space connect to it over SSH or with VS Code. Dev Mode is only available on upgraded hardware.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dev_mode_ssh_host": schema.StringAttribute{
+				MarkdownDescription: "The host to connect to over SSH while Dev Mode is enabled.",
+				Computed:            true,
+			},
+			"dev_mode_ssh_user": schema.StringAttribute{
+				MarkdownDescription: "The user to connect as over SSH while Dev Mode is enabled.",
+				Computed:            true,
+			},
+			"dev_mode_ssh_command": schema.StringAttribute{
+				MarkdownDescription: "The command to connect to the space over SSH while Dev Mode is enabled. The SSH key of the owner of the space must be added to their Hugging Face account.",
+				Computed:            true,
+			},
+			"restart_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that restart the space whenever any of them changes, such as the revision of a linked model.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"factory_reboot": schema.BoolAttribute{
+				MarkdownDescription: "Whether restarts caused by `restart_triggers` rebuild the image of the space from scratch rather than reusing the cached one.",
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Tags of the space. Tags the Hub adds by itself, such as the SDK, are not tracked once tags are configured.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"models": schema.ListAttribute{
+				MarkdownDescription: "IDs of the models the space uses, written into the `models` list of its card metadata. The Hub shows them in the space and links the space from their pages.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"datasets": schema.ListAttribute{
+				MarkdownDescription: "IDs of the datasets the space uses, written into the `datasets` list of its card metadata. The Hub shows them in the space and links the space from their pages.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"custom_domain": schema.StringAttribute{
+				MarkdownDescription: "A custom domain to serve the space from. The domain must be verified and the owner must be on a paid plan.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hostnameRegexp, "must be a valid hostname"),
+				},
+			},
+			"card_metadata": schema.SingleNestedAttribute{
+				MarkdownDescription: "Metadata rendered into the YAML frontmatter of the `README.md` of the space, which controls how the space is displayed and run. Keys that are not set here are left as they are.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"title": schema.StringAttribute{
+						MarkdownDescription: "The title displayed on the card of the space.",
+						Optional:            true,
+					},
+					"emoji": schema.StringAttribute{
+						MarkdownDescription: "The emoji displayed on the card of the space.",
+						Optional:            true,
+					},
+					"color_from": schema.StringAttribute{
+						MarkdownDescription: "The start color of the thumbnail gradient, one of `" + strings.Join(cardColors, "`, `") + "`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(cardColors...),
+						},
+					},
+					"color_to": schema.StringAttribute{
+						MarkdownDescription: "The end color of the thumbnail gradient, one of `" + strings.Join(cardColors, "`, `") + "`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(cardColors...),
+						},
+					},
+					"pinned": schema.BoolAttribute{
+						MarkdownDescription: "Whether the space stays on top of the list of spaces of its owner, set in its card. Cannot be set along with the top-level `pinned`, which pins the space through the API and should be preferred.",
+						Optional:            true,
+					},
+					"license": schema.StringAttribute{
+						MarkdownDescription: "The license of the space, such as `mit` or `apache-2.0`.",
+						Optional:            true,
+					},
+					"app_file": schema.StringAttribute{
+						MarkdownDescription: "The path of the main application file, such as `app.py`.",
+						Optional:            true,
+					},
+					"python_version": schema.StringAttribute{
+						MarkdownDescription: "The Python version the space runs with, such as `3.10`.",
+						Optional:            true,
+					},
+				},
+			},
+			"gated":  gatedAttribute("space"),
+			"gating": gatingAttribute("space"),
+			"cleanup_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete all secrets and variables of the space before the space itself is destroyed.",
+				Optional:            true,
+			},
+			"wait_for_deletion": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the space waits until the API no longer returns it.",
+				Optional:            true,
+			},
+			"allow_storage_deletion": schema.BoolAttribute{
+				MarkdownDescription: "Whether removing `storage` or moving to a smaller tier may delete the persistent storage of the space, and all the data stored on it. Plans that would do so fail otherwise.",
+				Optional:            true,
+			},
+			"scheduled_hardware": schema.StringAttribute{
+				MarkdownDescription: "The hardware flavor the space is requested on according to its `schedule`, null without a schedule.",
+				Computed:            true,
+			},
+			"oauth_client_id": schema.StringAttribute{
+				MarkdownDescription: "The client ID of the OAuth app of the space, null unless the `oauth` block is set. It is also available to the space as the `OAUTH_CLIENT_ID` environment variable.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether the space, along with its persistent storage, is protected from being deleted or replaced. Destroying a protected space fails until this is removed or set to `false` and applied.",
+				Optional:            true,
 			},
+			"manage_secrets_exclusively": schema.BoolAttribute{
+				MarkdownDescription: "Whether secrets not present in `secrets` are deleted from the space. Set to `false` to leave secrets managed outside of Terraform untouched. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"schedule": spaceScheduleBlock(),
+			"oauth":    spaceOAuthBlock(),
+			"docker":   spaceDockerBlock(),
+			"static":   spaceStaticBlock(),
+			"wait_for": schema.SingleNestedBlock{
+				MarkdownDescription: "Makes create and update wait until the space reaches a stage, failing if the space fails to build or start instead.",
+				Attributes: map[string]schema.Attribute{
+					"stage": schema.StringAttribute{
+						MarkdownDescription: "The stage to wait for, one of `" + strings.Join(waitForStages, "`, `") + "`. Defaults to `RUNNING`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(waitForStages...),
+						},
+					},
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "How long to wait, as a duration such as `15m`. Defaults to `15m`.",
+						Optional:            true,
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
-}
+}
+
+func (r *SpaceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		spaceSleepTimeValidator{},
+	}
+}
+
+func (r *SpaceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if waitFor := waitForConfig(ctx, data.WaitFor, &resp.Diagnostics); waitFor != nil && !waitFor.Timeout.IsNull() && !waitFor.Timeout.IsUnknown() {
+		if _, err := time.ParseDuration(waitFor.Timeout.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("wait_for").AtName("timeout"),
+				"Invalid Timeout",
+				fmt.Sprintf("Timeout %q is not a valid duration, got error: %s", waitFor.Timeout.ValueString(), err),
+			)
+		}
+	}
+
+	if schedule := spaceSchedule(ctx, data.Schedule, &resp.Diagnostics); schedule != nil {
+		schedule.validate(&resp.Diagnostics)
+	}
+
+	validateSpaceSDKBlocks(ctx, &data, &resp.Diagnostics)
+
+	// A secret is either stored in state or write-only, never both.
+	if !data.Secrets.IsUnknown() && !data.SecretsWO.IsUnknown() {
+		secretsWO := data.SecretsWO.Elements()
+		for _, key := range sortedKeys(data.Secrets.Elements()) {
+			if _, ok := secretsWO[key]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("secrets_wo").AtMapKey(key),
+					"Duplicate Secret",
+					fmt.Sprintf("Secret %s is set in both secrets and secrets_wo, it can only be set in one of them.", key),
+				)
+			}
+		}
+	}
+
+	// A space is pinned either through the API or through its card, and
+	// pinned, which is read back from the space, is the one to use.
+	if !data.Pinned.IsNull() && !data.Pinned.IsUnknown() {
+		if metadata := cardMetadata(ctx, data.CardMetadata, &resp.Diagnostics); metadata != nil && !metadata.Pinned.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("card_metadata").AtName("pinned"),
+				"Conflicting Pinned Settings",
+				"pinned and card_metadata.pinned both pin the space, only one of them can be set. Prefer pinned, which pins the space through the API and detects changes made outside of Terraform.",
+			)
+		}
+	}
+
+	// The SDK version only applies to Gradio and Streamlit spaces, and ZeroGPU
+	// hardware only to Gradio spaces.
+	if !data.SDK.IsNull() && !data.SDK.IsUnknown() {
+		sdk := data.SDK.ValueString()
+		if !data.Hardware.IsUnknown() && canonicalHardware(data.Hardware.ValueString()) == zeroGPUHardware && sdk != "gradio" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hardware"),
+				"ZeroGPU Requires Gradio",
+				fmt.Sprintf("ZeroGPU hardware is only available to Gradio spaces, spaces using the %s SDK must request different hardware.", sdk),
+			)
+		}
+		if !data.SDKVersion.IsNull() && sdk != "gradio" && sdk != "streamlit" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("sdk_version"),
+				"SDK Version Ignored",
+				fmt.Sprintf("Only Gradio and Streamlit spaces use sdk_version, spaces using the %s SDK ignore it.", sdk),
+			)
+		}
+	}
+
+	if data.Region.IsNull() || data.Region.IsUnknown() || data.Hardware.IsNull() || data.Hardware.IsUnknown() {
+		return
+	}
+
+	regions, ok := hardwareRegions[canonicalHardware(data.Hardware.ValueString())]
+	if !ok {
+		return
+	}
+
+	for _, region := range regions {
+		if region == data.Region.ValueString() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("region"),
+		"Unsupported Hardware Region",
+		fmt.Sprintf("Hardware %q is not available in region %q, supported regions: %s.", data.Hardware.ValueString(), data.Region.ValueString(), strings.Join(regions, ", ")),
+	)
+}
+
+func (r *SpaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only deletion protection is checked when the resource is being
+	// destroyed, so that the plan already fails.
+	if req.Plan.Raw.IsNull() {
+		var deletionProtection types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+		if deletionProtection.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_protection"),
+				"Space Is Protected From Deletion",
+				"The space has deletion_protection enabled. Remove it or set it to false and apply the change before destroying or replacing the space.",
+			)
+		}
+		return
+	}
+
+	var config, plan SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planSpaceDefaults(ctx, &config, &plan, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planSpaceSchedule(ctx, &plan, time.Now(), resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *SpaceResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.planSpaceMove(ctx, state, &plan, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		planSpaceStorage(ctx, &config, state, &plan, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The OAuth app is only registered or removed once the card is
+		// committed.
+		if !plan.OAuth.Equal(state.OAuth) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("oauth_client_id"), types.StringUnknown())...)
+		}
+	}
+
+	r.planSpaceCost(ctx, state, &plan, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Hardware.IsNull() || plan.Hardware.IsUnknown() {
+		return
+	}
+
+	// Hardware is matched case-insensitively and common aliases are accepted.
+	// The configured spelling is kept in the plan, as Terraform does not allow
+	// providers to alter configured values, and the canonical flavor is used
+	// whenever the API is called. Unknown flavors are rejected by the
+	// hardware validator.
+	hardware, ok := normalizeHardware(plan.Hardware.ValueString())
+	if !ok {
+		return
+	}
+
+	if config.SleepTime.IsNull() {
+		return
+	}
+
+	// Configured free hardware is rejected by spaceSleepTimeValidator. Hardware
+	// that is computed or defaulted by the provider is not something the
+	// configuration asked for, so it only gets a warning.
+	if sleepTimeIgnoredHardware[hardware] {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("sleep_time"),
+			"Sleep Time Ignored",
+			fmt.Sprintf("Hardware %q does not support a custom sleep time, the configured sleep_time will be ignored.", plan.Hardware.ValueString()),
+		)
+	}
+}
+
+// planSpaceDefaults plans the provider defaults of the hardware, storage and
+// sleep time of a space that does not configure them. A defaulted storage tier
+// is treated as configured, so that it is not planned for removal.
+func (r *SpaceResource) planSpaceDefaults(ctx context.Context, config *SpaceResourceModel, plan *SpaceResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.Hardware.IsNull() && !r.defaults.Hardware.IsNull() {
+		plan.Hardware = r.defaults.Hardware
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("hardware"), plan.Hardware)...)
+	}
+
+	if config.Storage.IsNull() && !r.defaults.Storage.IsNull() {
+		config.Storage = r.defaults.Storage
+		plan.Storage = r.defaults.Storage
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage"), plan.Storage)...)
+	}
+
+	// Spaces on hardware that ignores the sleep time, including those left on
+	// the free hardware the Hub defaults to, would never converge on it, so the
+	// default sleep time only applies once the hardware is known.
+	hardware, _ := normalizeHardware(plan.Hardware.ValueString())
+	if plan.Hardware.IsNull() || plan.Hardware.IsUnknown() || sleepTimeIgnoredHardware[hardware] {
+		return
+	}
+
+	if config.SleepTime.IsNull() && !r.defaults.SleepTime.IsNull() {
+		plan.SleepTime = r.defaults.SleepTime
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sleep_time"), plan.SleepTime)...)
+	}
+}
+
+// planSpaceSchedule plans the hardware a space with a schedule is requested
+// on at now, so that applying the configuration moves the space between its
+// hardware and its off-hours hardware as the window opens and closes.
+func planSpaceSchedule(ctx context.Context, plan *SpaceResourceModel, now time.Time, resp *resource.ModifyPlanResponse) {
+	schedule := spaceSchedule(ctx, plan.Schedule, &resp.Diagnostics)
+
+	switch {
+	case schedule == nil && plan.Schedule.IsUnknown(), schedule != nil && plan.Hardware.IsUnknown():
+		plan.ScheduledHardware = types.StringUnknown()
+	case schedule == nil:
+		plan.ScheduledHardware = types.StringNull()
+	case plan.Hardware.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schedule"),
+			"Schedule Requires Hardware",
+			"A space with a schedule must set hardware, or the provider must set default_hardware, to run on during the window.",
+		)
+		return
+	default:
+		plan.ScheduledHardware = scheduledHardware(ctx, schedule, plan.Hardware, now, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("scheduled_hardware"), plan.ScheduledHardware)...)
+}
+
+// requestedHardware returns the hardware the space of data is requested on,
+// which is its scheduled hardware if it has a schedule, in its canonical
+// spelling.
+func requestedHardware(data *SpaceResourceModel) string {
+	if !data.ScheduledHardware.IsNull() && !data.ScheduledHardware.IsUnknown() {
+		return data.ScheduledHardware.ValueString()
+	}
+	return canonicalHardware(data.Hardware.ValueString())
+}
+
+// planSpaceMove plans the ID a space is moved to when its name or namespace
+// changes, warning that its URL changes with it.
+func (r *SpaceResource) planSpaceMove(ctx context.Context, state *SpaceResourceModel, plan *SpaceResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.Name.IsUnknown() || plan.Namespace.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("subdomain"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("author"), types.StringUnknown())...)
+		return
+	}
+
+	fromRepo := state.ID.ValueString()
+	toRepo := spaceMoveTarget(state, plan)
+	if toRepo == fromRepo {
+		return
+	}
+
+	// The subdomain, URL and author are derived from the ID by the Hub.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), toRepo)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("subdomain"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("author"), types.StringUnknown())...)
+	resp.Diagnostics.AddWarning(
+		"Space Will Be Moved",
+		fmt.Sprintf("Space %s will be moved to %s. Its URL changes accordingly, and links to the old URL only keep working as long as the Hub redirects them.", fromRepo, toRepo),
+	)
+}
+
+// planSpaceStorage plans the removal of the persistent storage of a space
+// whose storage is no longer configured, and fails plans that would delete
+// persistent storage unless this is explicitly allowed.
+func planSpaceStorage(ctx context.Context, config *SpaceResourceModel, state *SpaceResourceModel, plan *SpaceResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.Storage.IsNull() && !state.Storage.IsNull() {
+		plan.Storage = types.StringNull()
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage"), plan.Storage)...)
+	}
+
+	if !storageDeletion(state.Storage, plan.Storage) {
+		return
+	}
+
+	if !plan.AllowStorageDeletion.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("storage"),
+			"Storage Deletion Not Allowed",
+			fmt.Sprintf("Changing the storage of space %s from %q to %q deletes its persistent storage and all the data stored on it. Set allow_storage_deletion = true to allow this.", state.ID.ValueString(), state.Storage.ValueString(), plan.Storage.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("storage"),
+		"Persistent Storage Will Be Deleted",
+		fmt.Sprintf("The persistent storage of space %s and all the data stored on it will be deleted.", state.ID.ValueString()),
+	)
+}
+
+// storageDeletion reports whether changing the storage tier of a space from
+// one tier to another deletes its persistent storage, which is the case when
+// storage is removed or moved to a smaller tier.
+func storageDeletion(from types.String, to types.String) bool {
+	if from.IsNull() || from.IsUnknown() || to.IsUnknown() {
+		return false
+	}
+	if to.IsNull() {
+		return true
+	}
+
+	tier := func(name string) int {
+		for i, t := range storageTiers {
+			if t == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	return tier(to.ValueString()) < tier(from.ValueString())
+}
+
+// spaceMoveTarget returns the ID of the space planned in plan, whose current
+// ID is in state. A namespace that is neither configured nor known yet is
+// taken from the current ID.
+func spaceMoveTarget(state *SpaceResourceModel, plan *SpaceResourceModel) string {
+	namespace, _ := splitRepoID(state.ID.ValueString())
+	if !plan.Namespace.IsUnknown() && !plan.Namespace.IsNull() && plan.Namespace.ValueString() != "" {
+		namespace = plan.Namespace.ValueString()
+	}
+
+	return namespace + "/" + plan.Name.ValueString()
+}
+
+func (r *SpaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.maxConcurrentRequests = data.maxConcurrentRequests
+	r.defaults = data.spaceDefaults
+	r.prices = data.hardwarePrices
+}
+
+func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Write-only secrets are only available from the config.
+	secretsWO := writeOnlySecrets(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var spaceName string
+	if !data.DuplicateFrom.IsNull() {
+		spaceName = r.duplicateSpace(ctx, data, secretsWO, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		createReq := hfclient.CreateRepoRequest{
+			Type:         hfclient.RepoTypeSpace,
+			Name:         data.Name.ValueString(),
+			Organization: data.Namespace.ValueString(),
+			SDK:          data.SDK.ValueString(),
+			Template:     data.Template.ValueString(),
+			Hardware:     requestedHardware(data),
+			Storage:      data.Storage.ValueString(),
+			Region:       data.Region.ValueString(),
+		}
+
+		// Unknown values are left to the API rather than sent as zero values,
+		// which would make the space public and set a sleep time of 0.
+		if !data.Private.IsUnknown() {
+			createReq.Private = data.Private.ValueBoolPointer()
+		}
+		if !data.SleepTime.IsUnknown() {
+			createReq.SleepTime = data.SleepTime.ValueInt64Pointer()
+		}
+
+		if !data.Tags.IsUnknown() {
+			resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &createReq.Tags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// The idempotency key lets the API deduplicate the create request should
+		// it be resent after the space was in fact created.
+		idempotencyKey, err := uuid.GenerateUUID()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to generate idempotency key, got error: %s", err))
+			return
+		}
+		createReq.IdempotencyKey = idempotencyKey
+
+		created, err := r.client.CreateRepo(ctx, createReq)
+		if err != nil {
+			addHardwareError(&resp.Diagnostics, "create space", createReq.Hardware, err)
+			return
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Create Space Response: %+v", *created))
+
+		spaceName = created.Name
+		if spaceName == "" {
+			resp.Diagnostics.AddError("Invalid Response", "Unable to extract space name from create space response")
+			return
+		}
+
+		// The ID of a space is always of the form namespace/name.
+		if !strings.Contains(spaceName, "/") && !data.Namespace.IsUnknown() && !data.Namespace.IsNull() {
+			spaceName = fmt.Sprintf("%s/%s", data.Namespace.ValueString(), spaceName)
+		}
+	}
+
+	data.ID = types.StringValue(spaceName)
+
+	if data.Namespace.IsUnknown() {
+		namespace, _ := splitRepoID(spaceName)
+		data.Namespace = types.StringValue(namespace)
+	}
+
+	// Import the contents of the space from an external Git repository
+	if !data.FromGit.IsNull() {
+		r.importSpaceFromGit(ctx, data.ID.ValueString(), data.FromGit.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Add secrets, write-only secrets and variables. Duplicated spaces got
+	// their secrets and variables along with the duplicate request.
+	if data.DuplicateFrom.IsNull() {
+		var calls []concurrentCall
+		if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
+			secrets := data.Secrets.Elements()
+			for _, key := range sortedKeys(secrets) {
+				calls = append(calls, r.setSecretCall(data.ID.ValueString(), "add", key, secrets[key].(types.String).ValueString()))
+			}
+		}
+		for _, key := range sortedKeys(secretsWO) {
+			calls = append(calls, r.setSecretCall(data.ID.ValueString(), "add", key, secretsWO[key]))
+		}
+		if !data.Variables.IsNull() && !data.Variables.IsUnknown() {
+			variables := data.Variables.Elements()
+			for _, key := range sortedKeys(variables) {
+				calls = append(calls, r.setVariableCall(data.ID.ValueString(), "add", key, variables[key].(types.String).ValueString()))
+			}
+		}
+
+		runConcurrently(ctx, r.maxConcurrentRequests, calls, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, secretsWOPrivateKey, writeOnlySecretKeys(secretsWO))...)
+
+	if !data.CustomDomain.IsNull() {
+		setSpaceCustomDomain(ctx, r.client, data.ID.ValueString(), data.CustomDomain.ValueString(), path.Root("custom_domain"), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Newly created spaces are not gated.
+	if data.Gated.IsUnknown() {
+		data.Gated = types.StringValue(gatedDisabled)
+	} else if data.Gated.ValueString() != gatedDisabled {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeSpace, data.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.writeSpaceCard(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The storage tier only becomes current once the space has been built.
+	data.StorageCurrent = types.StringNull()
+
+	if data.Tags.IsUnknown() {
+		data.Tags = types.ListValueMust(types.StringType, []attr.Value{})
+	}
+
+	// Link models and datasets
+	if data.Models.IsUnknown() {
+		data.Models = types.ListValueMust(types.StringType, []attr.Value{})
+	} else if len(data.Models.Elements()) > 0 {
+		r.setSpaceCardList(ctx, data.ID.ValueString(), "models", data.Models, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.Datasets.IsUnknown() {
+		data.Datasets = types.ListValueMust(types.StringType, []attr.Value{})
+	} else if len(data.Datasets.Elements()) > 0 {
+		r.setSpaceCardList(ctx, data.ID.ValueString(), "datasets", data.Datasets, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Resolve the attributes that were left to the API by reading the space
+	// back. Configured values are kept as they are, as the space may still be
+	// building and not reflect all of them yet.
+	remote := *data
+	found := r.readSpace(ctx, &remote, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Space %s was not found after it was created", data.ID.ValueString()))
+		return
+	}
+
+	if data.Private.IsUnknown() {
+		data.Private = remote.Private
+	}
+	if data.SDK.IsUnknown() {
+		data.SDK = remote.SDK
+	}
+	if data.Template.IsUnknown() {
+		data.Template = remote.Template
+	}
+	if data.Hardware.IsUnknown() {
+		data.Hardware = remote.Hardware
+	}
+	if data.Storage.IsUnknown() {
+		data.Storage = remote.Storage
+	}
+	if data.SleepTime.IsUnknown() {
+		data.SleepTime = remote.SleepTime
+	}
+	data.Subdomain = remote.Subdomain
+	data.URL = remote.URL
+	data.SHA = remote.SHA
+	data.CreatedAt = remote.CreatedAt
+	data.Disabled = remote.Disabled
+	data.Author = remote.Author
+	data.Likes = remote.Likes
+	data.LastModified = remote.LastModified
+	data.OAuthClientID = remote.OAuthClientID
+	if data.EstimatedHourlyCost.IsUnknown() {
+		data.EstimatedHourlyCost = r.estimateHourlyCost(ctx, data)
+	}
+
+	// Pin the space. Newly created spaces are never pinned, so there is
+	// nothing to do unless pinning was requested.
+	if data.Pinned.IsUnknown() {
+		data.Pinned = types.BoolValue(false)
+	} else if data.Pinned.ValueBool() {
+		r.setSpacePinned(ctx, data.ID.ValueString(), true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Newly created spaces start running, so they only need to be paused.
+	if data.DesiredState.IsUnknown() {
+		data.DesiredState = types.StringValue("running")
+	} else if data.DesiredState.ValueString() == "paused" {
+		r.setSpaceDesiredState(ctx, data.ID.ValueString(), "paused", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Newly created spaces never start in Dev Mode.
+	if data.DevMode.IsUnknown() {
+		data.DevMode = types.BoolValue(false)
+	} else if data.DevMode.ValueBool() {
+		r.setSpaceDevMode(ctx, data.ID.ValueString(), true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	setSpaceDevModeSSH(data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The space is saved to state before waiting for it, so that a space
+	// which fails to build is tainted rather than forgotten.
+	r.waitForSpace(ctx, data.ID.ValueString(), data.WaitFor, &resp.Diagnostics)
+}
+
+func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readSpace(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The space was deleted outside of Terraform, so let the next plan
+	// create it again.
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	secretsWOKeys, diags := req.Private.GetKey(ctx, secretsWOPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readSpaceSecrets(ctx, data, secretsWOKeys, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readSpaceVariables(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SpaceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Settings that only affect the behavior of the provider are taken
+	// straight from the plan.
+	state.CleanupSecrets = data.CleanupSecrets
+	state.ManageSecretsExclusively = data.ManageSecretsExclusively
+	state.WaitForDeletion = data.WaitForDeletion
+	state.AllowStorageDeletion = data.AllowStorageDeletion
+	state.DeletionProtection = data.DeletionProtection
+	state.WaitFor = data.WaitFor
+	state.Timeouts = data.Timeouts
+	state.FactoryReboot = data.FactoryReboot
+
+	// Check if the space needs to be renamed or moved to another namespace.
+	// Both are a single move of the repository, which keeps its contents,
+	// settings and secrets.
+	if toRepo := spaceMoveTarget(&state, data); toRepo != state.ID.ValueString() {
+		tflog.Debug(ctx, fmt.Sprintf("Moving space %s to %s", state.ID.ValueString(), toRepo))
+
+		err := r.client.MoveRepo(ctx, hfclient.MoveRepoRequest{
+			FromRepo: state.ID.ValueString(),
+			ToRepo:   toRepo,
+			Type:     hfclient.RepoTypeSpace,
+		})
+		switch code := hfclient.StatusCode(err); {
+		case err == nil:
+		case code == http.StatusForbidden || code == http.StatusConflict:
+			// The token must be allowed to write to both namespaces, and the
+			// target name must be free.
+			fromNamespace, _ := splitRepoID(state.ID.ValueString())
+			toNamespace, _ := splitRepoID(toRepo)
+			attr := path.Root("name")
+			if toNamespace != fromNamespace {
+				attr = path.Root("namespace")
+			}
+			resp.Diagnostics.AddAttributeError(
+				attr,
+				"Space Move Rejected",
+				fmt.Sprintf("Unable to move space %s to %s, got %s", state.ID.ValueString(), toRepo, err),
+			)
+			return
+		default:
+			addClientError(&resp.Diagnostics, fmt.Sprintf("move space %s to %s", state.ID.ValueString(), toRepo), err)
+			return
+		}
+
+		namespace, _ := splitRepoID(toRepo)
+		state.ID = types.StringValue(toRepo)
+		state.Name = data.Name
+		state.Namespace = types.StringValue(namespace)
+	}
+
+	// The planned ID is unknown when the target of a move was not known at
+	// plan time, so all the operations that follow use the current ID.
+	data.ID = state.ID
+
+	// Check if the space visibility needs to be updated
+	if !data.Private.IsUnknown() && !data.Private.Equal(state.Private) {
+		err := r.client.UpdateRepoSettings(ctx, hfclient.RepoTypeSpace, data.ID.ValueString(), hfclient.RepoSettings{
+			Private: data.Private.ValueBoolPointer(),
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, "update space visibility", err)
+			return
+		}
+
+		state.Private = data.Private
+	}
+
+	// Check if the space needs to be pinned or unpinned
+	if !data.Pinned.IsUnknown() && state.Pinned.ValueBool() != data.Pinned.ValueBool() {
+		r.setSpacePinned(ctx, data.ID.ValueString(), data.Pinned.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Pinned = data.Pinned
+	}
+
+	// Check if the tags of the space need to be updated
+	if !data.Tags.IsUnknown() && !data.Tags.Equal(state.Tags) {
+		r.setSpaceListSetting(ctx, data.ID.ValueString(), "tags", data.Tags, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Tags = data.Tags
+	}
+
+	// Check if the linked models of the space need to be updated
+	if !data.Models.IsUnknown() && !data.Models.Equal(state.Models) {
+		r.setSpaceCardList(ctx, data.ID.ValueString(), "models", data.Models, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Models = data.Models
+	}
+
+	// Check if the linked datasets of the space need to be updated
+	if !data.Datasets.IsUnknown() && !data.Datasets.Equal(state.Datasets) {
+		r.setSpaceCardList(ctx, data.ID.ValueString(), "datasets", data.Datasets, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Datasets = data.Datasets
+	}
+
+	// Check if the custom domain of the space needs to be updated
+	if state.CustomDomain.ValueString() != data.CustomDomain.ValueString() {
+		setSpaceCustomDomain(ctx, r.client, data.ID.ValueString(), data.CustomDomain.ValueString(), path.Root("custom_domain"), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.CustomDomain = data.CustomDomain
+	}
+
+	// Check if the gating of the space needs to be updated
+	if !data.Gated.IsUnknown() && state.Gated.ValueString() != data.Gated.ValueString() {
+		setRepoGated(ctx, r.client, hfclient.RepoTypeSpace, data.ID.ValueString(), data.Gated.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Gated = data.Gated
+	}
+
+	// Check if the card of the space needs to be updated
+	if !data.CardMetadata.IsUnknown() && !data.SDKVersion.IsUnknown() && !data.AppPort.IsUnknown() && !data.Gating.IsUnknown() && !data.OAuth.IsUnknown() && !data.Docker.IsUnknown() && !data.Static.IsUnknown() &&
+		(!data.CardMetadata.Equal(state.CardMetadata) || !data.SDKVersion.Equal(state.SDKVersion) || !data.AppPort.Equal(state.AppPort) || !data.Gating.Equal(state.Gating) || !data.OAuth.Equal(state.OAuth) || !data.Docker.Equal(state.Docker) || !data.Static.Equal(state.Static)) {
+		if data.OAuth.IsNull() && !state.OAuth.IsNull() {
+			disableSpaceOAuth(ctx, r.client, data.ID.ValueString(), &resp.Diagnostics)
+		}
+
+		r.writeSpaceCard(ctx, data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.CardMetadata = data.CardMetadata
+		state.SDKVersion = data.SDKVersion
+		state.AppPort = data.AppPort
+		state.Gating = data.Gating
+		state.OAuth = data.OAuth
+		state.Docker = data.Docker
+		state.Static = data.Static
+	}
+
+	// Write-only secrets are taken from the config, and the keys that were
+	// sent before from the private state.
+	secretsWO := writeOnlySecrets(ctx, req.Config, &resp.Diagnostics)
+	previousSecretsWOKeys, diags := req.Private.GetKey(ctx, secretsWOPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previousSecretsWO []string
+	if len(previousSecretsWOKeys) > 0 {
+		if err := json.Unmarshal(previousSecretsWOKeys, &previousSecretsWO); err != nil {
+			resp.Diagnostics.AddError("Invalid Private State", fmt.Sprintf("Unable to decode the keys of write-only secrets, got error: %s", err))
+			return
+		}
+	}
+
+	// Update secrets. A null map leaves the secrets of the space unmanaged,
+	// whereas a known map, even an empty one, is reconciled exactly so that
+	// all secrets can be removed by configuring `secrets = {}`.
+	if data.Secrets.IsNull() {
+		state.Secrets = data.Secrets
+	} else if !data.Secrets.IsUnknown() {
+		planned := data.Secrets.Elements()
+		previous := state.Secrets.Elements()
+
+		// Secrets that were managed before but are no longer configured are
+		// deleted. Unless secrets are partly managed out of band, so are
+		// secrets that were added outside of Terraform. Secrets the API
+		// refuses to list are left in place.
+		// Write-only secrets are never stale.
+		stale := make(map[string]bool)
+		for key := range previous {
+			if _, ok := planned[key]; !ok {
+				stale[key] = true
+			}
+		}
+
+		if data.ManageSecretsExclusively.ValueBool() {
+			existingSecrets, err := r.client.ListSpaceSecrets(ctx, data.ID.ValueString())
+			if err != nil && hfclient.StatusCode(err) == 0 {
+				addClientError(&resp.Diagnostics, "retrieve secrets", err)
+				return
+			}
+
+			for key := range existingSecrets {
+				if _, ok := planned[key]; !ok {
+					stale[key] = true
+				}
+			}
+		}
+
+		for key := range secretsWO {
+			delete(stale, key)
+		}
+
+		var calls []concurrentCall
+		for _, key := range sortedKeys(stale) {
+			calls = append(calls, r.deleteSecretCall(data.ID.ValueString(), key))
+		}
+
+		// Only secrets that are new or whose value changed are set, as every
+		// change restarts the space.
+		for _, key := range sortedKeys(planned) {
+			if previousValue, ok := previous[key]; ok && previousValue.Equal(planned[key]) {
+				continue
+			}
+			calls = append(calls, r.setSecretCall(data.ID.ValueString(), "set", key, planned[key].(types.String).ValueString()))
+		}
+
+		runConcurrently(ctx, r.maxConcurrentRequests, calls, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Secrets = data.Secrets
+	}
+
+	// Update write-only secrets. Their values cannot be compared, so they are
+	// all sent again whenever their version changes, and otherwise only the
+	// keys that were added are.
+	var secretWOCalls []concurrentCall
+	sentSecretsWO := make(map[string]bool)
+	for _, key := range previousSecretsWO {
+		sentSecretsWO[key] = true
+
+		if _, ok := secretsWO[key]; ok {
+			continue
+		}
+		if _, ok := data.Secrets.Elements()[key]; ok {
+			continue
+		}
+		secretWOCalls = append(secretWOCalls, r.deleteSecretCall(data.ID.ValueString(), key))
+	}
+
+	versionChanged := !data.SecretsWOVersion.Equal(state.SecretsWOVersion)
+	for _, key := range sortedKeys(secretsWO) {
+		if sentSecretsWO[key] && !versionChanged {
+			continue
+		}
+		secretWOCalls = append(secretWOCalls, r.setSecretCall(data.ID.ValueString(), "set", key, secretsWO[key]))
+	}
+
+	runConcurrently(ctx, r.maxConcurrentRequests, secretWOCalls, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.SecretsWOVersion = data.SecretsWOVersion
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, secretsWOPrivateKey, writeOnlySecretKeys(secretsWO))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update variables. Only variables that are new or whose value changed
+	// are set, and only those that are no longer configured are deleted, as
+	// every change restarts the space.
+	if !data.Variables.IsNull() && !data.Variables.IsUnknown() {
+		// Variables are compared with those of the space, or with the state
+		// if the API refuses to list them.
+		existingVariables, err := r.client.ListSpaceVariables(ctx, data.ID.ValueString())
+		if err != nil && hfclient.StatusCode(err) == 0 {
+			addClientError(&resp.Diagnostics, "retrieve variables", err)
+			return
+		}
+
+		existing := make(map[string]string)
+		if err == nil {
+			for key, variable := range existingVariables {
+				existing[key] = variable.Value
+			}
+		} else {
+			for key, value := range state.Variables.Elements() {
+				existing[key] = value.(types.String).ValueString()
+			}
+		}
+
+		variables := data.Variables.Elements()
+		var calls []concurrentCall
+		for _, key := range sortedKeys(existing) {
+			if _, ok := variables[key]; !ok {
+				calls = append(calls, r.deleteVariableCall(data.ID.ValueString(), key))
+			}
+		}
+		for _, key := range sortedKeys(variables) {
+			value := variables[key].(types.String).ValueString()
+			if existingValue, ok := existing[key]; ok && existingValue == value {
+				continue
+			}
+			calls = append(calls, r.setVariableCall(data.ID.ValueString(), "set", key, value))
+		}
+
+		runConcurrently(ctx, r.maxConcurrentRequests, calls, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Variables = data.Variables
+	}
+
+	// Check if the space hardware needs to be updated
+	hardware := requestedHardware(data)
+	if !data.Hardware.IsUnknown() && (requestedHardware(&state) != hardware || state.Region.ValueString() != data.Region.ValueString()) {
+		// Compare against the hardware the space has requested rather than
+		// the hardware it is currently running on, so that a space which is
+		// already migrating to the configured flavor is left alone.
+		runtime, err := r.client.GetSpaceRuntime(ctx, data.ID.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read space runtime", err)
+			return
+		}
+
+		requested := runtime.Hardware.Requested
+		if requested != nil && *requested == hardware && state.Region.ValueString() == data.Region.ValueString() {
+			tflog.Debug(ctx, fmt.Sprintf("Space hardware already requested as %s, skipping update", *requested))
+		} else if err := r.client.SetSpaceHardware(ctx, data.ID.ValueString(), hardware, data.Region.ValueString()); err != nil {
+			addHardwareError(&resp.Diagnostics, "update space hardware", hardware, err)
+			return
+		}
+
+		state.Hardware = data.Hardware
+		state.Region = data.Region
+	}
+	// The hardware of a space outside of its window may change without
+	// requesting anything.
+	if !data.Hardware.IsUnknown() {
+		state.Hardware = data.Hardware
+	}
+	state.Schedule = data.Schedule
+	state.ScheduledHardware = data.ScheduledHardware
+
+	// Check if the space storage needs to be updated. Storage can only grow,
+	// so removing it or moving to a smaller tier deletes it first, which the
+	// plan has checked to be allowed.
+	if !data.Storage.IsUnknown() && !data.Storage.Equal(state.Storage) {
+		if storageDeletion(state.Storage, data.Storage) {
+			if !data.AllowStorageDeletion.ValueBool() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("storage"),
+					"Storage Deletion Not Allowed",
+					fmt.Sprintf("Deleting the persistent storage of space %s requires allow_storage_deletion = true.", data.ID.ValueString()),
+				)
+				return
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Deleting persistent storage of space %s", data.ID.ValueString()))
+			if err := r.client.DeleteSpaceStorage(ctx, data.ID.ValueString()); err != nil && !hfclient.IsNotFound(err) {
+				addClientError(&resp.Diagnostics, "delete space storage", err)
+				return
+			}
+			state.StorageCurrent = types.StringNull()
+		}
+
+		if !data.Storage.IsNull() {
+			if err := r.client.SetSpaceStorage(ctx, data.ID.ValueString(), data.Storage.ValueString()); err != nil {
+				addClientError(&resp.Diagnostics, "update space storage", err)
+				return
+			}
+		}
+
+		state.Storage = data.Storage
+	}
+
+	// Check if the space sleep time needs to be updated. An unknown sleep
+	// time is left to the Hub.
+	if !data.SleepTime.IsUnknown() && !data.SleepTime.Equal(state.SleepTime) {
+		if err := r.client.SetSpaceSleepTime(ctx, data.ID.ValueString(), data.SleepTime.ValueInt64()); err != nil {
+			addClientError(&resp.Diagnostics, "update space sleep time", err)
+			return
+		}
+
+		state.SleepTime = data.SleepTime
+	}
+
+	// Check if the space needs to be paused or resumed
+	if !data.DesiredState.IsUnknown() && state.DesiredState.ValueString() != data.DesiredState.ValueString() {
+		r.setSpaceDesiredState(ctx, data.ID.ValueString(), data.DesiredState.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.DesiredState = data.DesiredState
+	} else if !data.RestartTriggers.Equal(state.RestartTriggers) && !state.RestartTriggers.IsNull() && state.DesiredState.ValueString() != "paused" {
+		// Only a running space whose triggers changed is restarted. Setting
+		// triggers for the first time does not restart it, resuming a space
+		// restarts it already, and a paused space is restarted once it is
+		// resumed.
+		tflog.Debug(ctx, fmt.Sprintf("Restart triggers of space %s changed, restarting it", data.ID.ValueString()))
+		if err := r.client.RestartSpace(ctx, data.ID.ValueString(), data.FactoryReboot.ValueBool()); err != nil {
+			addClientError(&resp.Diagnostics, "restart space", err)
+			return
+		}
+	}
+	state.RestartTriggers = data.RestartTriggers
+
+	// Check if Dev Mode needs to be enabled or disabled
+	if !data.DevMode.IsUnknown() && state.DevMode.ValueBool() != data.DevMode.ValueBool() {
+		r.setSpaceDevMode(ctx, data.ID.ValueString(), data.DevMode.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.DevMode = data.DevMode
+	}
+
+	// The commits and moves made above change attributes computed by the
+	// Hub, so they are read back.
+	space, err := r.client.GetSpace(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read space", err)
+		return
+	}
+	setSpaceComputed(&state, space)
+	setSpaceDevModeSSH(&state)
+
+	state.EstimatedHourlyCost = data.EstimatedHourlyCost
+	if state.EstimatedHourlyCost.IsUnknown() {
+		state.EstimatedHourlyCost = r.estimateHourlyCost(ctx, &state)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.waitForSpace(ctx, state.ID.ValueString(), state.WaitFor, &resp.Diagnostics)
+}
+
+func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Space Is Protected From Deletion",
+			fmt.Sprintf("Space %s has deletion_protection enabled. Remove it or set it to false and apply the change before destroying or replacing the space.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Failing to clean up an individual secret or variable is reported as a
+	// warning only, the space is deleted regardless.
+	if data.CleanupSecrets.ValueBool() {
+		r.cleanupSpaceKeys(ctx, data.ID.ValueString(), "secrets", &resp.Diagnostics)
+		r.cleanupSpaceKeys(ctx, data.ID.ValueString(), "variables", &resp.Diagnostics)
+	}
+
+	// The space is deleted by its ID rather than its configured name, so
+	// that spaces owned by an organization are deleted from the right
+	// namespace. A space that is already gone is as good as deleted.
+	namespace, name := splitRepoID(data.ID.ValueString())
+	err := r.client.DeleteRepo(ctx, hfclient.DeleteRepoRequest{
+		Type:         hfclient.RepoTypeSpace,
+		Name:         name,
+		Organization: namespace,
+	})
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete space", err)
+		return
+	}
+
+	// The space may still be returned for a short while after it has been
+	// deleted, so optionally wait until it is really gone.
+	if data.WaitForDeletion.ValueBool() {
+		if err := r.waitForSpaceDeletion(ctx, data.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to confirm deletion of space %s, got error: %s", data.ID.ValueString(), err))
+			return
+		}
+	}
+}
+
+// waitForSpaceDeletion polls a space until the API reports it as not found
+// or the context is done.
+func (r *SpaceResource) waitForSpaceDeletion(ctx context.Context, spaceID string) error {
+	for {
+		_, err := r.client.GetSpace(ctx, spaceID)
+		if hfclient.IsNotFound(err) {
+			return nil
+		}
+
+		// Other API errors are retried, the space may be in the middle of
+		// being deleted.
+		if err != nil && hfclient.StatusCode(err) == 0 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(deletePollInterval):
+		}
+	}
+}
+
+// waitForConfig returns the wait_for block, or nil if it is not configured.
+func waitForConfig(ctx context.Context, block types.Object, diags *diag.Diagnostics) *SpaceWaitForModel {
+	if block.IsNull() || block.IsUnknown() {
+		return nil
+	}
+
+	var waitFor SpaceWaitForModel
+	diags.Append(block.As(ctx, &waitFor, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &waitFor
+}
+
+// waitForSpace waits for a space to reach the stage configured in the
+// wait_for block, if any.
+func (r *SpaceResource) waitForSpace(ctx context.Context, spaceID string, block types.Object, diags *diag.Diagnostics) {
+	waitFor := waitForConfig(ctx, block, diags)
+	if waitFor == nil {
+		return
+	}
+
+	stage := defaultWaitForStage
+	if !waitFor.Stage.IsNull() {
+		stage = waitFor.Stage.ValueString()
+	}
+
+	timeout := defaultWaitForTimeout
+	if !waitFor.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(waitFor.Timeout.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("wait_for").AtName("timeout"), "Invalid Timeout", fmt.Sprintf("Unable to parse timeout, got error: %s", err))
+			return
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.waitForSpaceStage(waitCtx, spaceID, stage); err != nil {
+		diags.AddError("Space Not Ready", fmt.Sprintf("Space %s did not reach stage %s, got error: %s", spaceID, stage, err))
+	}
+}
+
+// waitForSpaceStage polls the runtime of a space until it reaches stage, a
+// stage the space cannot recover from by itself, or the context is done.
+func (r *SpaceResource) waitForSpaceStage(ctx context.Context, spaceID string, stage string) error {
+	for {
+		runtime, err := r.client.GetSpaceRuntime(ctx, spaceID)
+		if err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Space %s is in stage %s, waiting for %s", spaceID, runtime.Stage, stage))
+
+		if runtime.Stage == stage {
+			return nil
+		}
+
+		if failedStages[runtime.Stage] {
+			reason := "no reason given"
+			if runtime.ErrorMessage != nil {
+				reason = *runtime.ErrorMessage
+			}
+			return fmt.Errorf("space is in stage %s: %s", runtime.Stage, reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last stage: %s", ctx.Err(), runtime.Stage)
+		case <-time.After(waitForPollInterval):
+		}
+	}
+}
+
+// duplicateSpace duplicates the space data is planned to be duplicated from,
+// returning the ID of the new space. The secrets and variables of data, as
+// well as the write-only secretsWO, are set on the new space right away.
+func (r *SpaceResource) duplicateSpace(ctx context.Context, data *SpaceResourceModel, secretsWO map[string]string, diags *diag.Diagnostics) string {
+	namespace := data.Namespace.ValueString()
+	if data.Namespace.IsUnknown() {
+		whoami, err := r.client.WhoAmI(ctx)
+		if err != nil {
+			addClientError(diags, "read authenticated user", err)
+			return ""
+		}
+		namespace = whoami.Name
+	}
+
+	in := hfclient.DuplicateSpaceRequest{
+		Repository: namespace + "/" + data.Name.ValueString(),
+		Hardware:   requestedHardware(data),
+		Storage:    data.Storage.ValueString(),
+	}
+	if !data.Private.IsUnknown() {
+		in.Private = data.Private.ValueBoolPointer()
+	}
+	if !data.SleepTime.IsUnknown() {
+		in.SleepTime = data.SleepTime.ValueInt64Pointer()
+	}
+
+	if !data.Secrets.IsUnknown() {
+		for _, key := range sortedKeys(data.Secrets.Elements()) {
+			in.AddSecret(key, data.Secrets.Elements()[key].(types.String).ValueString())
+		}
+	}
+	for _, key := range sortedKeys(secretsWO) {
+		in.AddSecret(key, secretsWO[key])
+	}
+	if !data.Variables.IsUnknown() {
+		for _, key := range sortedKeys(data.Variables.Elements()) {
+			in.AddVariable(key, data.Variables.Elements()[key].(types.String).ValueString())
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Duplicating space %s to %s", data.DuplicateFrom.ValueString(), in.Repository))
+
+	if err := r.client.DuplicateSpace(ctx, data.DuplicateFrom.ValueString(), in); err != nil {
+		if hfclient.IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("duplicate_from"),
+				"Space Not Found",
+				fmt.Sprintf("Space %s to duplicate does not exist or is not visible to the provider token.", data.DuplicateFrom.ValueString()),
+			)
+			return ""
+		}
+
+		addHardwareError(diags, fmt.Sprintf("duplicate space %s", data.DuplicateFrom.ValueString()), in.Hardware, err)
+		return ""
+	}
+
+	// Tags cannot be set along with the duplicate request.
+	if !data.Tags.IsUnknown() && len(data.Tags.Elements()) > 0 {
+		r.setSpaceListSetting(ctx, in.Repository, "tags", data.Tags, diags)
+	}
+
+	return in.Repository
+}
+
+// importSpaceFromGit imports the contents of an external Git repository into
+// a space.
+func (r *SpaceResource) importSpaceFromGit(ctx context.Context, spaceID string, gitURL string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, fmt.Sprintf("Importing space %s from Git repository %s", spaceID, gitURL))
+
+	err := r.client.ImportSpaceFromGit(ctx, spaceID, gitURL)
+	if err == nil {
+		return
+	}
+
+	switch code := hfclient.StatusCode(err); code {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		diags.AddAttributeError(
+			path.Root("from_git"),
+			"Importing From Git Unsupported",
+			fmt.Sprintf("The Hub at %s does not support importing spaces from Git, got status code: %d. Space %s was created empty.", r.client.Endpoint(), code, spaceID),
+		)
+	default:
+		addClientError(diags, "import space from Git", err)
+	}
+}
+
+// readSpace refreshes data with the space as returned by the API. It reports
+// whether the space exists.
+func (r *SpaceResource) readSpace(ctx context.Context, data *SpaceResourceModel, diags *diag.Diagnostics) bool {
+	space, err := r.client.GetSpace(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		addClientError(diags, "read space", err)
+		return false
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read Space Response: %+v", space))
+
+	namespace, _ := splitRepoID(data.ID.ValueString())
+	data.Namespace = types.StringValue(namespace)
+
+	setSpaceComputed(data, space)
+
+	if space.Private != nil {
+		data.Private = types.BoolValue(*space.Private)
+	}
+
+	if space.SDK != nil {
+		data.SDK = types.StringValue(*space.SDK)
+	}
+
+	// The template a space was created from is not returned by the API.
+	if data.Template.IsUnknown() {
+		data.Template = types.StringNull()
+	}
+
+	if space.Pinned != nil {
+		data.Pinned = types.BoolValue(*space.Pinned)
+	}
+
+	if !data.CustomDomain.IsNull() || space.CustomDomain != nil {
+		data.CustomDomain = types.StringPointerValue(space.CustomDomain)
+	}
+
+	data.Gated = types.StringValue(gatedMode(space.Gated))
+
+	r.readSpaceCard(ctx, data, diags)
+	if diags.HasError() {
+		return false
+	}
 
-func (r *SpaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
-		return
+	// Tags the Hub adds by itself would otherwise show up as a diff, so once
+	// tags are tracked only the tracked ones that are still present are kept.
+	if !data.Tags.IsNull() && !data.Tags.IsUnknown() && len(data.Tags.Elements()) > 0 {
+		remoteTags := make(map[string]bool, len(space.Tags))
+		for _, tag := range space.Tags {
+			remoteTags[tag] = true
+		}
+
+		var tags []attr.Value
+		for _, tag := range data.Tags.Elements() {
+			if remoteTags[tag.(types.String).ValueString()] {
+				tags = append(tags, tag)
+			}
+		}
+		data.Tags = types.ListValueMust(types.StringType, tags)
+	} else {
+		tags, d := stringListValue(ctx, space.Tags)
+		diags.Append(d...)
+		if diags.HasError() {
+			return false
+		}
+		data.Tags = tags
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	models, d := stringListValue(ctx, space.Models)
+	diags.Append(d...)
+	datasets, d := stringListValue(ctx, space.Datasets)
+	diags.Append(d...)
+	if diags.HasError() {
+		return false
+	}
+	data.Models = models
+	data.Datasets = datasets
+
+	if space.Runtime != nil {
+		// The configured spelling of the hardware is kept as long as it
+		// refers to the flavor the space has requested.
+		hardware := space.Runtime.Hardware.Requested
+		if hardware == nil {
+			hardware = space.Runtime.Hardware.Current
+		}
+		// Spaces with a schedule are compared against the hardware they
+		// are scheduled on instead, so that leaving the window shows up as
+		// a change of the scheduled hardware only.
+		switch {
+		case hardware == nil:
+		case !data.ScheduledHardware.IsNull() && !data.ScheduledHardware.IsUnknown():
+			data.ScheduledHardware = types.StringValue(*hardware)
+		case canonicalHardware(data.Hardware.ValueString()) != *hardware:
+			data.Hardware = types.StringValue(*hardware)
+		}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
+		// The requested storage tier is what the user configures, the
+		// current tier is only exposed so transitions between tiers do not
+		// show up as a diff on storage.
+		data.Storage = types.StringPointerValue(space.Runtime.Storage.Requested)
+		data.StorageCurrent = types.StringPointerValue(space.Runtime.Storage.Current)
 
-		return
+		if space.Runtime.SleepTime != nil {
+			data.SleepTime = types.Int64Value(*space.Runtime.SleepTime)
+		} else {
+			data.SleepTime = types.Int64Value(sleepTimeNever)
+		}
+
+		// Every stage but PAUSED, including a space that went to sleep or
+		// failed, is one the space was left running in.
+		if space.Runtime.Stage == "PAUSED" {
+			data.DesiredState = types.StringValue("paused")
+		} else {
+			data.DesiredState = types.StringValue("running")
+		}
+
+		data.DevMode = types.BoolValue(space.Runtime.DevMode != nil && *space.Runtime.DevMode)
 	}
+	setSpaceDevModeSSH(data)
 
-	r.client = client
+	return true
 }
 
-func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *SpaceResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+// readSpaceSecrets refreshes the keys of the managed secrets of data from the
+// API. Secret values are never returned, so values are kept as they are, but
+// secrets deleted outside of Terraform are dropped and, unless secrets are
+// partly managed out of band, secrets added outside of Terraform are tracked
+// with a null value so that the next plan shows both. secretsWOKeys lists the
+// keys of the write-only secrets, which are not tracked. Secrets the API
+// refuses to list are left as they are.
+func (r *SpaceResource) readSpaceSecrets(ctx context.Context, data *SpaceResourceModel, secretsWOKeys []byte, diags *diag.Diagnostics) {
+	if data.Secrets.IsNull() || data.Secrets.IsUnknown() {
+		return
+	}
 
-	if resp.Diagnostics.HasError() {
+	remote, err := r.client.ListSpaceSecrets(ctx, data.ID.ValueString())
+	if err != nil && hfclient.StatusCode(err) != 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list secrets of space %s, leaving them as they are: %s", data.ID.ValueString(), err))
+		return
+	}
+	if err != nil {
+		addClientError(diags, "read secrets", err)
 		return
 	}
 
-	url := "https://huggingface.co/api/repos/create"
+	var secretsWO []string
+	if len(secretsWOKeys) > 0 {
+		if err := json.Unmarshal(secretsWOKeys, &secretsWO); err != nil {
+			diags.AddError("Invalid Private State", fmt.Sprintf("Unable to decode the keys of write-only secrets, got error: %s", err))
+			return
+		}
+	}
+	writeOnly := make(map[string]bool, len(secretsWO))
+	for _, key := range secretsWO {
+		writeOnly[key] = true
+	}
 
-	reqBody := fmt.Sprintf(`{"type": "space", "name": "%s", "private": %t, "sdk": "%s", "template": "%s", "hardware": "%s", "storage": "%s", "sleepTime": %d}`,
-		data.Name.ValueString(),
-		data.Private.ValueBool(),
-		data.SDK.ValueString(),
-		data.Template.ValueString(),
-		data.Hardware.ValueString(),
-		data.Storage.ValueString(),
-		data.SleepTime.ValueInt64(),
-	)
+	secrets := make(map[string]attr.Value, len(remote))
+	for key, value := range data.Secrets.Elements() {
+		if _, ok := remote[key]; ok {
+			secrets[key] = value
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Secret %s of space %s was deleted outside of Terraform", key, data.ID.ValueString()))
+		}
+	}
 
-	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space, got error: %s", err))
+	if data.ManageSecretsExclusively.ValueBool() {
+		for key := range remote {
+			if _, ok := secrets[key]; ok || writeOnly[key] {
+				continue
+			}
+			tflog.Debug(ctx, fmt.Sprintf("Secret %s of space %s was added outside of Terraform", key, data.ID.ValueString()))
+			secrets[key] = types.StringNull()
+		}
+	}
+
+	value, d := types.MapValue(types.StringType, secrets)
+	diags.Append(d...)
+	if diags.HasError() {
 		return
 	}
-	defer httpResp.Body.Close()
+	data.Secrets = value
+}
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create space, got status code: %d", httpResp.StatusCode))
+// readSpaceVariables refreshes the managed variables of data from the API.
+// Variables are managed exclusively, so variables added, deleted or changed
+// outside of Terraform all show up in the next plan. Variables the API
+// refuses to list are left as they are.
+func (r *SpaceResource) readSpaceVariables(ctx context.Context, data *SpaceResourceModel, diags *diag.Diagnostics) {
+	if data.Variables.IsNull() || data.Variables.IsUnknown() {
 		return
 	}
 
-	var responseData map[string]interface{}
-	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	remote, err := r.client.ListSpaceVariables(ctx, data.ID.ValueString())
+	if err != nil && hfclient.StatusCode(err) != 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list variables of space %s, leaving them as they are: %s", data.ID.ValueString(), err))
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode create space response, got error: %s", err))
+		addClientError(diags, "read variables", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Create Space Response: %+v", responseData)
+	variables := make(map[string]attr.Value, len(remote))
+	for key, variable := range remote {
+		variables[key] = types.StringValue(variable.Value)
+	}
 
-	spaceName, ok := responseData["name"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Invalid Response", "Unable to extract space name from create space response")
+	value, d := types.MapValue(types.StringType, variables)
+	diags.Append(d...)
+	if diags.HasError() {
 		return
 	}
+	data.Variables = value
+}
 
-	data.ID = types.StringValue(spaceName)
-
-	// Add secrets
-	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
-		secretsMap := data.Secrets.Elements()
-		for key, value := range secretsMap {
-			secretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
-			secretReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
-			secretResp, err := r.client.Post(secretURL, "application/json", strings.NewReader(secretReqBody))
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add secret, got error: %s", err))
-				return
-			}
-			defer secretResp.Body.Close()
+// setSpaceComputed refreshes the attributes of data that are only computed by
+// the Hub from space.
+func setSpaceComputed(data *SpaceResourceModel, space *hfclient.Space) {
+	data.Subdomain = types.StringPointerValue(space.Subdomain)
+	data.SHA = types.StringPointerValue(space.SHA)
+	data.CreatedAt = types.StringPointerValue(space.CreatedAt)
+	data.Disabled = types.BoolValue(space.Disabled != nil && *space.Disabled)
+	data.Author = types.StringPointerValue(space.Author)
+	data.Likes = types.Int64PointerValue(space.Likes)
+	data.LastModified = types.StringPointerValue(space.LastModified)
+	data.OAuthClientID = types.StringNull()
+	if space.OAuth != nil && space.OAuth.ClientID != "" {
+		data.OAuthClientID = types.StringValue(space.OAuth.ClientID)
+	}
+	data.URL = types.StringNull()
+	if url := spaceURL(space); url != "" {
+		data.URL = types.StringValue(url)
+	}
+}
 
-			if secretResp.StatusCode != http.StatusOK {
-				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add secret, got status code: %d", secretResp.StatusCode))
-				return
-			}
-		}
+// setSpaceDevModeSSH sets the SSH connection details of data, which are only
+// known while Dev Mode is enabled.
+func setSpaceDevModeSSH(data *SpaceResourceModel) {
+	if !data.DevMode.ValueBool() || data.Subdomain.IsNull() {
+		data.DevModeSSHHost = types.StringNull()
+		data.DevModeSSHUser = types.StringNull()
+		data.DevModeSSHCommand = types.StringNull()
+		return
 	}
 
-	// Add variables
-	if !data.Variables.IsNull() && !data.Variables.IsUnknown() {
-		variablesMap := data.Variables.Elements()
-		for key, value := range variablesMap {
-			variableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
-			variableReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
-			variableResp, err := r.client.Post(variableURL, "application/json", strings.NewReader(variableReqBody))
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add variable, got error: %s", err))
-				return
-			}
-			defer variableResp.Body.Close()
+	user := data.Subdomain.ValueString()
+	data.DevModeSSHHost = types.StringValue(devModeSSHHost)
+	data.DevModeSSHUser = types.StringValue(user)
+	data.DevModeSSHCommand = types.StringValue(fmt.Sprintf("ssh %s@%s", user, devModeSSHHost))
+}
 
-			if variableResp.StatusCode != http.StatusOK {
-				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add variable, got status code: %d", variableResp.StatusCode))
-				return
-			}
-		}
+// writeOnlySecrets returns the write-only secrets configured in config, which
+// are never part of the plan.
+func writeOnlySecrets(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) map[string]string {
+	var secrets types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("secrets_wo"), &secrets)...)
+	if secrets.IsNull() || secrets.IsUnknown() {
+		return nil
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	values := make(map[string]string)
+	diags.Append(secrets.ElementsAs(ctx, &values, false)...)
+
+	return values
 }
 
-func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *SpaceResourceModel
+// writeOnlySecretKeys encodes the keys of secrets for the private state.
+func writeOnlySecretKeys(secrets map[string]string) []byte {
+	keys, _ := json.Marshal(sortedKeys(secrets))
+	return keys
+}
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+// spaceURL returns the URL a space is served from, or an empty string if it
+// is not known. The host returned by the Hub is preferred, as it is the one
+// Enterprise Hub deployments serve from.
+func spaceURL(space *hfclient.Space) string {
+	if space.Host != nil && *space.Host != "" {
+		return *space.Host
+	}
+	if space.Subdomain != nil && *space.Subdomain != "" {
+		return "https://" + *space.Subdomain + ".hf.space"
+	}
+	return ""
+}
 
-	if resp.Diagnostics.HasError() {
+// writeSpaceCard renders card_metadata, sdk_version, app_port, gating, oauth
+// and the SDK blocks into the card of a space, committing the card if it
+// changed.
+func (r *SpaceResource) writeSpaceCard(ctx context.Context, data *SpaceResourceModel, diags *diag.Diagnostics) {
+	metadata := cardMetadata(ctx, data.CardMetadata, diags)
+	gating := repoGating(ctx, data.Gating, diags)
+	oauth := spaceOAuth(ctx, data.OAuth, diags)
+	docker := spaceDocker(ctx, data.Docker, diags)
+	static := spaceStatic(ctx, data.Static, diags)
+	if diags.HasError() || (metadata == nil && gating == nil && oauth == nil && docker == nil && static == nil && data.SDKVersion.IsNull() && data.AppPort.IsNull()) {
 		return
 	}
 
-	// ... (Retrieve space details using the GET /api/spaces/{space_id} endpoint)
+	card, err := loadRepoCard(ctx, r.client, hfclient.RepoTypeSpace, data.ID.ValueString())
+	if err != nil {
+		addClientError(diags, "read space card", err)
+		return
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	if metadata != nil {
+		writeCardMetadata(card, metadata)
+	}
+	if !data.SDKVersion.IsNull() {
+		card.set("sdk_version", data.SDKVersion.ValueString())
+	}
+	if !data.AppPort.IsNull() {
+		card.set("app_port", data.AppPort.ValueInt64())
+	}
+	if gating != nil {
+		writeCardGating(ctx, card, gating, diags)
+		if diags.HasError() {
+			return
+		}
+	}
+	if oauth != nil {
+		writeCardOAuth(ctx, card, oauth, diags)
+		if diags.HasError() {
+			return
+		}
+	}
+	if docker != nil {
+		writeCardDocker(card, docker)
+	}
+	if static != nil {
+		writeCardStatic(card, static)
+	}
 
-func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *SpaceResourceModel
+	tflog.Debug(ctx, fmt.Sprintf("Updating card of space %s", data.ID.ValueString()))
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if err := card.save(ctx, r.client, hfclient.RepoTypeSpace, data.ID.ValueString()); err != nil {
+		addClientError(diags, "update space card", err)
+	}
+}
 
-	if resp.Diagnostics.HasError() {
+// readSpaceCard refreshes card_metadata, sdk_version, app_port, gating, oauth
+// and the SDK blocks from the card of a space. Only what is managed is read
+// back.
+func (r *SpaceResource) readSpaceCard(ctx context.Context, data *SpaceResourceModel, diags *diag.Diagnostics) {
+	metadata := cardMetadata(ctx, data.CardMetadata, diags)
+	gating := repoGating(ctx, data.Gating, diags)
+	oauth := spaceOAuth(ctx, data.OAuth, diags)
+	docker := spaceDocker(ctx, data.Docker, diags)
+	static := spaceStatic(ctx, data.Static, diags)
+	if diags.HasError() || (metadata == nil && gating == nil && oauth == nil && docker == nil && static == nil && data.SDKVersion.IsNull() && data.AppPort.IsNull()) {
 		return
 	}
 
-	var state SpaceResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
+	card, err := loadRepoCard(ctx, r.client, hfclient.RepoTypeSpace, data.ID.ValueString())
+	if err != nil {
+		addClientError(diags, "read space card", err)
 		return
 	}
 
-	// Check if the space needs to be renamed
-	if state.Name.ValueString() != data.Name.ValueString() {
-		url := "https://huggingface.co/api/repos/move"
-
-		fromRepo := state.ID.ValueString()
-		toRepo := fmt.Sprintf("%s/%s", strings.Split(state.ID.ValueString(), "/")[0], data.Name.ValueString())
-
-		reqBody := fmt.Sprintf(`{"fromRepo": "%s", "toRepo": "%s", "type": "space"}`, fromRepo, toRepo)
-		log.Printf("[DEBUG] Rename Space Request Body: %s", reqBody)
+	if metadata != nil {
+		readCardMetadata(card, metadata)
 
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename space, got error: %s", err))
+		object, d := types.ObjectValueFrom(ctx, cardMetadataAttrTypes, metadata)
+		diags.Append(d...)
+		if diags.HasError() {
 			return
 		}
-		defer httpResp.Body.Close()
-
-		log.Printf("[DEBUG] Rename Space Response Status Code: %d", httpResp.StatusCode)
+		data.CardMetadata = object
+	}
 
-		respBody, err := ioutil.ReadAll(httpResp.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("API Response Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-			return
+	if !data.SDKVersion.IsNull() {
+		data.SDKVersion = types.StringNull()
+		if node := card.get("sdk_version"); node != nil {
+			data.SDKVersion = types.StringValue(node.Value)
 		}
-		log.Printf("[DEBUG] Rename Space Response Body: %s", string(respBody))
+	}
 
-		if httpResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to rename space, got status code: %d", httpResp.StatusCode))
-			return
+	if !data.AppPort.IsNull() {
+		data.AppPort = types.Int64Null()
+		if node := card.get("app_port"); node != nil {
+			var port int64
+			if err := node.Decode(&port); err == nil {
+				data.AppPort = types.Int64Value(port)
+			}
 		}
-
-		state.ID = types.StringValue(toRepo)
-		state.Name = data.Name
 	}
 
-	// Check if the space visibility needs to be updated
-	if state.Private != data.Private {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/settings", data.ID.ValueString())
-
-		reqBody := fmt.Sprintf(`{"private": %t}`, data.Private.ValueBool())
-		log.Printf("[DEBUG] Update Space Visibility Request Body: %s", reqBody)
+	if gating != nil {
+		readCardGating(ctx, card, gating, diags)
 
-		httpReq, err := http.NewRequest(http.MethodPut, url, strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space visibility, got error: %s", err))
+		object, d := types.ObjectValueFrom(ctx, gatingAttrTypes, gating)
+		diags.Append(d...)
+		if diags.HasError() {
 			return
 		}
-		httpReq.Header.Set("Content-Type", "application/json")
+		data.Gating = object
+	}
 
-		httpResp, err := r.client.Do(httpReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space visibility, got error: %s", err))
+	if docker != nil {
+		readCardDocker(card, docker)
+
+		object, d := types.ObjectValueFrom(ctx, dockerAttrTypes, docker)
+		diags.Append(d...)
+		if diags.HasError() {
 			return
 		}
-		defer httpResp.Body.Close()
+		data.Docker = object
+	}
 
-		log.Printf("[DEBUG] Update Space Visibility Response Status Code: %d", httpResp.StatusCode)
+	if static != nil {
+		readCardStatic(card, static)
 
-		respBody, err := ioutil.ReadAll(httpResp.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("API Response Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
+		object, d := types.ObjectValueFrom(ctx, staticAttrTypes, static)
+		diags.Append(d...)
+		if diags.HasError() {
 			return
 		}
-		log.Printf("[DEBUG] Update Space Visibility Response Body: %s", string(respBody))
+		data.Static = object
+	}
 
-		if httpResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space visibility, got status code: %d", httpResp.StatusCode))
+	if oauth != nil {
+		// Spaces whose OAuth was disabled outside of Terraform lose the block,
+		// so that it is planned to be enabled again.
+		if !readCardOAuth(ctx, card, oauth, diags) {
+			data.OAuth = types.ObjectNull(oauthAttrTypes)
 			return
 		}
-	}
 
-	// Update secrets
-	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
-		// Delete existing secrets
-		secretsURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
-		secretsResp, err := r.client.Get(secretsURL)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve secrets, got error: %s", err))
+		object, d := types.ObjectValueFrom(ctx, oauthAttrTypes, oauth)
+		diags.Append(d...)
+		if diags.HasError() {
 			return
 		}
-		defer secretsResp.Body.Close()
+		data.OAuth = object
+	}
+}
 
-		if secretsResp.StatusCode == http.StatusOK {
-			var existingSecrets map[string]interface{}
-			err = json.NewDecoder(secretsResp.Body).Decode(&existingSecrets)
-			if err != nil {
-				resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode secrets response, got error: %s", err))
-				return
-			}
+// setSpaceDesiredState pauses a space, or restarts it to resume it.
+func (r *SpaceResource) setSpaceDesiredState(ctx context.Context, spaceID string, desiredState string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, fmt.Sprintf("Setting space %s to %s", spaceID, desiredState))
 
-			for key := range existingSecrets {
-				deleteSecretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
-				deleteSecretReqBody := fmt.Sprintf(`{"key": "%s"}`, key)
-				deleteSecretReq, err := http.NewRequest(http.MethodDelete, deleteSecretURL, strings.NewReader(deleteSecretReqBody))
-				if err != nil {
-					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret, got error: %s", err))
-					return
-				}
-				deleteSecretReq.Header.Set("Content-Type", "application/json")
+	if desiredState == "paused" {
+		if err := r.client.PauseSpace(ctx, spaceID); err != nil {
+			addClientError(diags, "pause space", err)
+		}
+		return
+	}
 
-				deleteSecretResp, err := r.client.Do(deleteSecretReq)
-				if err != nil {
-					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret, got error: %s", err))
-					return
-				}
-				defer deleteSecretResp.Body.Close()
+	if err := r.client.RestartSpace(ctx, spaceID, false); err != nil {
+		addClientError(diags, "resume space", err)
+	}
+}
 
-				if deleteSecretResp.StatusCode != http.StatusOK {
-					resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete secret, got status code: %d", deleteSecretResp.StatusCode))
-					return
-				}
-			}
-		}
+// setSpaceDevMode enables or disables Dev Mode on a space.
+func (r *SpaceResource) setSpaceDevMode(ctx context.Context, spaceID string, enabled bool, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, fmt.Sprintf("Setting Dev Mode of space %s to %t", spaceID, enabled))
 
-		// Add new secrets
-		secretsMap := data.Secrets.Elements()
-		stateSecretsMap := make(map[string]attr.Value)
-		for key, value := range secretsMap {
-			secretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
-			secretReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
-			secretResp, err := r.client.Post(secretURL, "application/json", strings.NewReader(secretReqBody))
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add secret, got error: %s", err))
-				return
-			}
-			defer secretResp.Body.Close()
+	err := r.client.SetSpaceDevMode(ctx, spaceID, enabled)
+	if err == nil {
+		return
+	}
 
-			if secretResp.StatusCode != http.StatusOK {
-				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add secret, got status code: %d", secretResp.StatusCode))
-				return
-			}
-			stateSecretsMap[key] = value
-		}
-		state.Secrets, _ = types.MapValue(types.StringType, stateSecretsMap)
+	// The API rejects Dev Mode on free hardware and for users who are not
+	// allowed to use it with a 4xx and a message explaining why.
+	if code := hfclient.StatusCode(err); code >= 400 && code < 500 && code != http.StatusNotFound {
+		diags.AddAttributeError(
+			path.Root("dev_mode"),
+			"Dev Mode Rejected",
+			fmt.Sprintf("Unable to set Dev Mode of space %s, got %s", spaceID, err),
+		)
+		return
 	}
 
-	// Update variables
-	if !data.Variables.IsNull() && !data.Variables.IsUnknown() {
-		// Delete existing variables
-		variablesURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
-		variablesResp, err := r.client.Get(variablesURL)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve variables, got error: %s", err))
-			return
-		}
-		defer variablesResp.Body.Close()
+	addClientError(diags, "update space Dev Mode", err)
+}
 
-		if variablesResp.StatusCode == http.StatusOK {
-			var existingVariables map[string]interface{}
-			err = json.NewDecoder(variablesResp.Body).Decode(&existingVariables)
-			if err != nil {
-				resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode variables response, got error: %s", err))
-				return
-			}
+// setSpacePinned pins or unpins a space through the settings endpoint.
+func (r *SpaceResource) setSpacePinned(ctx context.Context, spaceID string, pinned bool, diags *diag.Diagnostics) {
+	err := r.client.UpdateRepoSettings(ctx, hfclient.RepoTypeSpace, spaceID, hfclient.RepoSettings{
+		Pinned: &pinned,
+	})
+
+	switch {
+	case err == nil:
+	case hfclient.StatusCode(err) == http.StatusForbidden:
+		diags.AddAttributeError(
+			path.Root("pinned"),
+			"Space Not Owned",
+			fmt.Sprintf("Space %s can only be pinned or unpinned by its owner.", spaceID),
+		)
+	default:
+		addClientError(diags, "update space pinned flag", err)
+	}
+}
 
-			for key := range existingVariables {
-				deleteVariableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
-				deleteVariableReqBody := fmt.Sprintf(`{"key": "%s"}`, key)
-				deleteVariableReq, err := http.NewRequest(http.MethodDelete, deleteVariableURL, strings.NewReader(deleteVariableReqBody))
-				if err != nil {
-					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete variable, got error: %s", err))
-					return
-				}
-				deleteVariableReq.Header.Set("Content-Type", "application/json")
+// setSpaceCustomDomain points a custom domain at a space through the settings
+// endpoint, reporting rejected domains on attr. An empty domain removes the
+// custom domain.
+func setSpaceCustomDomain(ctx context.Context, client *hfclient.Client, spaceID string, domain string, attr path.Path, diags *diag.Diagnostics) {
+	err := client.UpdateRepoSettings(ctx, hfclient.RepoTypeSpace, spaceID, hfclient.RepoSettings{
+		CustomDomain: &domain,
+	})
+	if err == nil {
+		return
+	}
 
-				deleteVariableResp, err := r.client.Do(deleteVariableReq)
-				if err != nil {
-					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete variable, got error: %s", err))
-					return
-				}
-				defer deleteVariableResp.Body.Close()
+	// The API rejects domains that are not verified or not available on the
+	// plan of the owner with a 4xx and a message explaining why.
+	if code := hfclient.StatusCode(err); code >= 400 && code < 500 {
+		diags.AddAttributeError(
+			attr,
+			"Custom Domain Rejected",
+			fmt.Sprintf("Unable to use custom domain %q for space %s, got %s", domain, spaceID, err),
+		)
+		return
+	}
 
-				if deleteVariableResp.StatusCode != http.StatusOK {
-					resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete variable, got status code: %d", deleteVariableResp.StatusCode))
-					return
-				}
-			}
-		}
+	addClientError(diags, "update space custom domain", err)
+}
 
-		// Add new variables
-		variablesMap := data.Variables.Elements()
-		stateVariablesMap := make(map[string]attr.Value)
-		for key, value := range variablesMap {
-			variableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
-			variableReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
-			variableResp, err := r.client.Post(variableURL, "application/json", strings.NewReader(variableReqBody))
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add variable, got error: %s", err))
-				return
-			}
-			defer variableResp.Body.Close()
+// setSpaceListSetting sets a list valued setting, such as the tags of a
+// space, through the settings endpoint.
+func (r *SpaceResource) setSpaceListSetting(ctx context.Context, spaceID string, name string, list types.List, diags *diag.Diagnostics) {
+	values := []string{}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return
+	}
 
-			if variableResp.StatusCode != http.StatusOK {
-				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add variable, got status code: %d", variableResp.StatusCode))
-				return
-			}
-			stateVariablesMap[key] = value
-		}
-		state.Variables, _ = types.MapValue(types.StringType, stateVariablesMap)
+	var settings hfclient.RepoSettings
+	switch name {
+	case "tags":
+		settings.Tags = &values
+	}
 
+	if err := r.client.UpdateRepoSettings(ctx, hfclient.RepoTypeSpace, spaceID, settings); err != nil {
+		addClientError(diags, fmt.Sprintf("update space %s", name), err)
 	}
+}
 
-	// Check if the space hardware needs to be updated
-	if state.Hardware.ValueString() != data.Hardware.ValueString() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/hardware", data.ID.ValueString())
-		reqBody := fmt.Sprintf(`{"flavor": "%s"}`, data.Hardware.ValueString())
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space hardware, got error: %s", err))
-			return
-		}
-		defer httpResp.Body.Close()
+// setSpaceCardList sets a list of the card metadata of a space, such as the
+// models it links to, committing the card if it changed. The Hub derives the
+// models and datasets of a space from its card.
+func (r *SpaceResource) setSpaceCardList(ctx context.Context, spaceID string, key string, list types.List, diags *diag.Diagnostics) {
+	values := []string{}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return
+	}
 
-		if httpResp.StatusCode != http.StatusOK {
-			respBody, _ := ioutil.ReadAll(httpResp.Body)
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space hardware, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
-			return
-		}
+	card, err := loadRepoCard(ctx, r.client, hfclient.RepoTypeSpace, spaceID)
+	if err != nil {
+		addClientError(diags, "read space card", err)
+		return
+	}
+	card.set(key, values)
 
-		var hardwareResp map[string]interface{}
-		err = json.NewDecoder(httpResp.Body).Decode(&hardwareResp)
-		if err != nil {
-			resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode update space hardware response, got error: %s", err))
-			return
-		}
+	tflog.Debug(ctx, fmt.Sprintf("Updating %s of space %s", key, spaceID))
 
-		state.Hardware = data.Hardware
+	if err := card.save(ctx, r.client, hfclient.RepoTypeSpace, spaceID); err != nil {
+		addClientError(diags, fmt.Sprintf("update space %s", key), err)
 	}
+}
 
-	// Check if the space storage needs to be updated
-	if state.Storage.ValueString() != data.Storage.ValueString() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/storage", data.ID.ValueString())
-		reqBody := fmt.Sprintf(`{"tier": "%s"}`, data.Storage.ValueString())
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space storage, got error: %s", err))
-			return
-		}
-		defer httpResp.Body.Close()
+// setSecretCall returns a call setting the secret key of a space, described
+// as verb ("add" or "set") in diagnostics.
+func (r *SpaceResource) setSecretCall(spaceID string, verb string, key string, value string) concurrentCall {
+	return concurrentCall{
+		action: fmt.Sprintf("%s secret %s", verb, key),
+		run: func(ctx context.Context) error {
+			tflog.Debug(ctx, fmt.Sprintf("Setting secret %s of space %s", key, spaceID))
+			return r.client.SetSpaceSecret(ctx, spaceID, key, value, "")
+		},
+	}
+}
 
-		if httpResp.StatusCode != http.StatusOK {
-			respBody, _ := ioutil.ReadAll(httpResp.Body)
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space storage, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
-			return
-		}
+// deleteSecretCall returns a call deleting the secret key of a space. Secrets
+// that are already gone are ignored.
+func (r *SpaceResource) deleteSecretCall(spaceID string, key string) concurrentCall {
+	return concurrentCall{
+		action: fmt.Sprintf("delete secret %s", key),
+		run: func(ctx context.Context) error {
+			tflog.Debug(ctx, fmt.Sprintf("Deleting secret %s from space %s", key, spaceID))
+			if err := r.client.DeleteSpaceSecret(ctx, spaceID, key); err != nil && !hfclient.IsNotFound(err) {
+				return err
+			}
+			return nil
+		},
+	}
+}
 
-		var storageResp map[string]interface{}
-		err = json.NewDecoder(httpResp.Body).Decode(&storageResp)
-		if err != nil {
-			resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode update space storage response, got error: %s", err))
-			return
-		}
+// deleteVariableCall returns a call deleting the variable key of a space.
+// Variables that are already gone are ignored.
+func (r *SpaceResource) deleteVariableCall(spaceID string, key string) concurrentCall {
+	return concurrentCall{
+		action: fmt.Sprintf("delete variable %s", key),
+		run: func(ctx context.Context) error {
+			tflog.Debug(ctx, fmt.Sprintf("Deleting variable %s from space %s", key, spaceID))
+			if err := r.client.DeleteSpaceVariable(ctx, spaceID, key); err != nil && !hfclient.IsNotFound(err) {
+				return err
+			}
+			return nil
+		},
+	}
+}
 
-		state.Storage = data.Storage
+// setVariableCall returns a call setting the variable key of a space,
+// described as verb ("add" or "set") in diagnostics.
+func (r *SpaceResource) setVariableCall(spaceID string, verb string, key string, value string) concurrentCall {
+	return concurrentCall{
+		action: fmt.Sprintf("%s variable %s", verb, key),
+		run: func(ctx context.Context) error {
+			tflog.Debug(ctx, fmt.Sprintf("Setting variable %s of space %s", key, spaceID))
+			return r.client.SetSpaceVariable(ctx, spaceID, key, value, "")
+		},
 	}
+}
 
-	// Check if the space sleep time needs to be updated
-	if state.SleepTime.ValueInt64() != data.SleepTime.ValueInt64() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/sleeptime", data.ID.ValueString())
-		reqBody := fmt.Sprintf(`{"seconds": %d}`, data.SleepTime.ValueInt64())
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+// cleanupSpaceKeys deletes every entry of kind ("secrets" or "variables")
+// from a space, reporting failures as warnings.
+func (r *SpaceResource) cleanupSpaceKeys(ctx context.Context, spaceID string, kind string, diags *diag.Diagnostics) {
+	var keys []string
+	deleteKey := r.client.DeleteSpaceSecret
+
+	switch kind {
+	case "secrets":
+		secrets, err := r.client.ListSpaceSecrets(ctx, spaceID)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space sleep time, got error: %s", err))
+			diags.AddWarning("Cleanup Error", fmt.Sprintf("Unable to list %s of space %s, got error: %s", kind, spaceID, err))
 			return
 		}
-		defer httpResp.Body.Close()
-
-		if httpResp.StatusCode != http.StatusOK {
-			respBody, _ := ioutil.ReadAll(httpResp.Body)
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space sleep time, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
-			return
+		for key := range secrets {
+			keys = append(keys, key)
 		}
-
-		var sleepTimeResp map[string]interface{}
-		err = json.NewDecoder(httpResp.Body).Decode(&sleepTimeResp)
+	case "variables":
+		variables, err := r.client.ListSpaceVariables(ctx, spaceID)
 		if err != nil {
-			resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode update space sleep time response, got error: %s", err))
+			diags.AddWarning("Cleanup Error", fmt.Sprintf("Unable to list %s of space %s, got error: %s", kind, spaceID, err))
 			return
 		}
-
-		state.SleepTime = data.SleepTime
+		for key := range variables {
+			keys = append(keys, key)
+		}
+		deleteKey = r.client.DeleteSpaceVariable
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-}
-
-func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *SpaceResourceModel
-
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
+	for _, key := range keys {
+		if err := deleteKey(ctx, spaceID, key); err != nil {
+			diags.AddWarning("Cleanup Error", fmt.Sprintf("Unable to delete %s key %s, got error: %s", kind, key, err))
+		}
 	}
+}
 
-	url := "https://huggingface.co/api/repos/delete"
-
-	reqBody := fmt.Sprintf(`{"type": "space", "name": "%s"}`, data.Name.ValueString())
-
-	httpReq, err := http.NewRequest(http.MethodDelete, url, strings.NewReader(reqBody))
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
+// ImportState imports a space by its ID. Everything the API returns is read
+// by the Read that follows, but the keys of secrets and variables are only
+// known here, so they are imported along with the values of variables. Secret
+// values are never returned by the API and are imported as null.
+func (r *SpaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !repoIDRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name, got: %q", req.ID),
+		)
 		return
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
+	namespace, name := splitRepoID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manage_secrets_exclusively"), true)...)
+
+	secrets, err := r.client.ListSpaceSecrets(ctx, req.ID)
+	switch {
+	case err != nil && hfclient.StatusCode(err) != 0 && !hfclient.IsNotFound(err):
+		resp.Diagnostics.AddWarning(
+			"Secrets Not Imported",
+			fmt.Sprintf("Unable to list secrets of space %s, got %s", req.ID, err),
+		)
+	case err != nil && !hfclient.IsNotFound(err):
+		addClientError(&resp.Diagnostics, "read secrets", err)
 		return
+	case len(secrets) > 0:
+		values := make(map[string]attr.Value, len(secrets))
+		for key := range secrets {
+			values[key] = types.StringNull()
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secrets"), types.MapValueMust(types.StringType, values))...)
+		resp.Diagnostics.AddWarning(
+			"Secret Values Not Imported",
+			fmt.Sprintf("The API never returns secret values, so secrets %s of space %s were imported without their values. The next apply sets them to the configured values.", strings.Join(sortedKeys(secrets), ", "), req.ID),
+		)
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete space, got status code: %d", httpResp.StatusCode))
+	variables, err := r.client.ListSpaceVariables(ctx, req.ID)
+	switch {
+	case err != nil && hfclient.StatusCode(err) != 0 && !hfclient.IsNotFound(err):
+		resp.Diagnostics.AddWarning(
+			"Variables Not Imported",
+			fmt.Sprintf("Unable to list variables of space %s, got %s", req.ID, err),
+		)
+	case err != nil && !hfclient.IsNotFound(err):
+		addClientError(&resp.Diagnostics, "read variables", err)
 		return
+	case len(variables) > 0:
+		values := make(map[string]attr.Value, len(variables))
+		for key, variable := range variables {
+			values[key] = types.StringValue(variable.Value)
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("variables"), types.MapValueMust(types.StringType, values))...)
 	}
 }
-
-func (r *SpaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
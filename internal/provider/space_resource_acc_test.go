@@ -0,0 +1,118 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+func TestAccSpaceResource(t *testing.T) {
+	hub := testutil.NewHub(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSpaceDestroyed(hub, "testutil/acc-space"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: hub.ProviderConfig() + `
+resource "huggingface-spaces_space" "test" {
+  name      = "acc-space"
+  sdk       = "gradio"
+  private   = true
+  hardware  = "cpu-upgrade"
+  variables = {
+    MODEL = "gpt2"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "id", "testutil/acc-space"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "namespace", "testutil"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "private", "true"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "hardware", "cpu-upgrade"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "variables.MODEL", "gpt2"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "url", "https://testutil-acc-space.hf.space"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "huggingface-spaces_space.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: hub.ProviderConfig() + `
+resource "huggingface-spaces_space" "test" {
+  name      = "acc-space"
+  sdk       = "gradio"
+  private   = false
+  hardware  = "t4-small"
+  variables = {
+    MODEL = "gpt2-large"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "private", "false"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "hardware", "t4-small"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "variables.MODEL", "gpt2-large"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSpaceDestroyed checks that the space spaceID is gone from hub.
+func testAccCheckSpaceDestroyed(hub *testutil.Hub, spaceID string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if hub.Space(spaceID) != nil {
+			return fmt.Errorf("space %s still exists", spaceID)
+		}
+		return nil
+	}
+}
+
+func TestAccSpaceResource_organization(t *testing.T) {
+	hub := testutil.NewHub(t)
+
+	// A space of the user with the same name must survive the deletion of
+	// the space of the organization.
+	hub.PutSpace(testutil.Space{ID: "testutil/shared-name", SDK: "static"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckSpaceDestroyed(hub, "testutil-org/shared-name"),
+			func(*terraform.State) error {
+				if hub.Space("testutil/shared-name") == nil {
+					return fmt.Errorf("space testutil/shared-name of the user was deleted")
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: hub.ProviderConfig() + `
+resource "huggingface-spaces_space" "test" {
+  name      = "shared-name"
+  namespace = "testutil-org"
+  sdk       = "static"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "id", "testutil-org/shared-name"),
+					resource.TestCheckResourceAttr("huggingface-spaces_space.test", "namespace", "testutil-org"),
+				),
+			},
+		},
+	})
+}
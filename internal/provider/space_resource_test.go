@@ -0,0 +1,1251 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/provider/testutil"
+)
+
+// testSpaceType is the type name of the space resource.
+const testSpaceType = "huggingface-spaces_space"
+
+// stringList returns a list of values.
+func stringList(values ...string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// testSpaceConfig returns the configuration of a space named name that sets
+// every attribute, so that creating it leaves nothing unknown.
+func testSpaceConfig(name string) map[string]attr.Value {
+	return map[string]attr.Value{
+		"name":       types.StringValue(name),
+		"private":    types.BoolValue(false),
+		"sdk":        types.StringValue("gradio"),
+		"template":   types.StringValue(""),
+		"hardware":   types.StringValue("cpu-upgrade"),
+		"storage":    types.StringValue("small"),
+		"sleep_time": types.Int64Value(172800),
+	}
+}
+
+// createTestSpace creates the space testuser/<name> of config against api,
+// which then serves the space as a new one.
+func createTestSpace(t *testing.T, api *mockAPI, config map[string]attr.Value) *testResource {
+	t.Helper()
+
+	name := config["name"].(types.String).ValueString()
+	api.handle(http.MethodPost, "/api/repos/create", http.StatusOK, `{"name": "testuser/`+name+`"}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/"+name, http.StatusOK, `{"id": "testuser/`+name+`", "author": "testuser"}`)
+
+	space := newMockProvider(t, api).resource(testSpaceType)
+	requireNoErrors(t, "create", space.apply(config))
+
+	return space
+}
+
+func TestSpaceResourceLifecycle(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":     types.StringValue("lifecycle"),
+		"sdk":      types.StringValue("gradio"),
+		"private":  types.BoolValue(true),
+		"hardware": types.StringValue("cpu-upgrade"),
+		"variables": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"MODEL": types.StringValue("gpt2"),
+		}),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	if id := space.stringAttribute("id"); id != "testutil/lifecycle" {
+		t.Fatalf("id = %q, expected testutil/lifecycle", id)
+	}
+	created := hub.Space("testutil/lifecycle")
+	if created == nil {
+		t.Fatal("space was not created")
+	}
+	if !created.Private || created.Hardware != "cpu-upgrade" || created.Variables["MODEL"].Value != "gpt2" {
+		t.Errorf("space was created as %+v", created)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	config["private"] = types.BoolValue(false)
+	config["hardware"] = types.StringValue("t4-small")
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("gpt2-large"),
+	})
+	requireNoErrors(t, "update", space.apply(config))
+
+	updated := hub.Space("testutil/lifecycle")
+	if updated.Private || updated.Hardware != "t4-small" || updated.Variables["MODEL"].Value != "gpt2-large" {
+		t.Errorf("space was updated to %+v", updated)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+
+	imported := newHubProvider(t, hub).resource(testSpaceType)
+	requireNoErrors(t, "import", imported.importState("testutil/lifecycle"))
+	for _, name := range []string{"id", "name", "namespace", "private", "sdk", "hardware", "variables", "url"} {
+		if !imported.attribute(name).Equal(space.attribute(name)) {
+			t.Errorf("imported %s = %s, expected %s", name, imported.attribute(name), space.attribute(name))
+		}
+	}
+
+	requireNoErrors(t, "destroy", space.destroy())
+	if hub.Space("testutil/lifecycle") != nil {
+		t.Error("space was not deleted")
+	}
+}
+
+func TestSpaceResourceRemovedOutsideOfTerraform(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	requireNoErrors(t, "create", space.apply(map[string]attr.Value{
+		"name": types.StringValue("removed"),
+		"sdk":  types.StringValue("static"),
+	}))
+
+	hub.DeleteSpace("testutil/removed")
+
+	requireNoErrors(t, "refresh", space.refresh())
+	if !space.state.IsNull() {
+		t.Error("space deleted outside of Terraform was not removed from state")
+	}
+}
+
+func TestSpaceResourceDeleteOrganizationSpace(t *testing.T) {
+	hub := testutil.NewHub(t)
+	hub.PutSpace(testutil.Space{ID: "testutil/shared-name", SDK: "static"})
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	requireNoErrors(t, "create", space.apply(map[string]attr.Value{
+		"name":      types.StringValue("shared-name"),
+		"namespace": types.StringValue("testutil-org"),
+		"sdk":       types.StringValue("static"),
+	}))
+	if hub.Space("testutil-org/shared-name") == nil {
+		t.Fatal("space was not created in the organization")
+	}
+
+	requireNoErrors(t, "destroy", space.destroy())
+
+	if hub.Space("testutil-org/shared-name") != nil {
+		t.Error("space of the organization was not deleted")
+	}
+	if hub.Space("testutil/shared-name") == nil {
+		t.Error("space of the user with the same name was deleted")
+	}
+
+	deletes := hub.Requests(http.MethodDelete, "/api/repos/delete")
+	if len(deletes) != 1 {
+		t.Fatalf("sent %d delete requests, expected 1", len(deletes))
+	}
+	var deleted hfclient.DeleteRepoRequest
+	if err := json.Unmarshal(deletes[0].Body, &deleted); err != nil {
+		t.Fatal(err)
+	}
+	if deleted.Name != "shared-name" || deleted.Organization != "testutil-org" {
+		t.Errorf("deleted %+v, expected shared-name of testutil-org", deleted)
+	}
+}
+
+func TestSpaceResourceHardwareAlreadyRequested(t *testing.T) {
+	api := newMockAPI(t)
+	config := testSpaceConfig("migrating")
+	space := createTestSpace(t, api, config)
+
+	// The space is already migrating to the configured hardware, and still
+	// runs on its current hardware.
+	api.handle(http.MethodGet, "/api/spaces/testuser/migrating/runtime", http.StatusOK,
+		`{"stage": "RUNNING", "hardware": {"current": "cpu-upgrade", "requested": "t4-small"}}`)
+
+	config["hardware"] = types.StringValue("t4-small")
+	requireNoErrors(t, "update", space.apply(config))
+
+	if requests := api.requestsTo(http.MethodPost, "/api/spaces/testuser/migrating/hardware"); len(requests) != 0 {
+		t.Errorf("sent %d hardware requests for hardware that is already requested", len(requests))
+	}
+	if hardware := space.stringAttribute("hardware"); hardware != "t4-small" {
+		t.Errorf("hardware = %q, expected t4-small", hardware)
+	}
+}
+
+func TestSpaceResourceHardwareRequested(t *testing.T) {
+	api := newMockAPI(t)
+	config := testSpaceConfig("upgrading")
+	space := createTestSpace(t, api, config)
+
+	api.handle(http.MethodGet, "/api/spaces/testuser/upgrading/runtime", http.StatusOK,
+		`{"stage": "RUNNING", "hardware": {"current": "cpu-upgrade", "requested": "cpu-upgrade"}}`)
+	api.handle(http.MethodPost, "/api/spaces/testuser/upgrading/hardware", http.StatusOK, `{}`)
+
+	config["hardware"] = types.StringValue("t4-small")
+	requireNoErrors(t, "update", space.apply(config))
+
+	requests := api.requestsTo(http.MethodPost, "/api/spaces/testuser/upgrading/hardware")
+	if len(requests) != 1 {
+		t.Fatalf("sent %d hardware requests, expected 1", len(requests))
+	}
+	if body := requests[0].Body; body != `{"flavor":"t4-small"}` {
+		t.Errorf("hardware request body = %s", body)
+	}
+}
+
+func TestSpaceResourceHardwareRegion(t *testing.T) {
+	api := newMockAPI(t)
+	config := testSpaceConfig("regional")
+	space := createTestSpace(t, api, config)
+
+	api.handle(http.MethodGet, "/api/spaces/testuser/regional/runtime", http.StatusOK,
+		`{"stage": "RUNNING", "hardware": {"current": "cpu-basic", "requested": "cpu-basic"}}`)
+	api.handle(http.MethodPost, "/api/spaces/testuser/regional/hardware", http.StatusOK, `{}`)
+
+	config["hardware"] = types.StringValue("t4-small")
+	config["region"] = types.StringValue("eu")
+	requireNoErrors(t, "update", space.apply(config))
+
+	requests := api.requestsTo(http.MethodPost, "/api/spaces/testuser/regional/hardware")
+	if len(requests) != 1 {
+		t.Fatalf("sent %d hardware requests, expected 1", len(requests))
+	}
+	var requested struct {
+		Flavor string `json:"flavor"`
+		Region string `json:"region"`
+	}
+	if err := json.Unmarshal([]byte(requests[0].Body), &requested); err != nil {
+		t.Fatal(err)
+	}
+	if requested.Flavor != "t4-small" || requested.Region != "eu" {
+		t.Errorf("requested %+v, expected t4-small in eu", requested)
+	}
+
+	// Hardware only offered in some regions is rejected elsewhere.
+	config["hardware"] = types.StringValue("h100")
+	_, _, diags := space.plan(config)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Unsupported Hardware Region") == nil {
+		t.Errorf("plan did not reject h100 in eu, got %v", diags)
+	}
+}
+
+func TestSpaceResourceCreateIdempotencyKey(t *testing.T) {
+	api := newMockAPI(t)
+	createTestSpace(t, api, testSpaceConfig("first"))
+	createTestSpace(t, api, testSpaceConfig("second"))
+
+	requests := api.requestsTo(http.MethodPost, "/api/repos/create")
+	if len(requests) != 2 {
+		t.Fatalf("sent %d create requests, expected 2", len(requests))
+	}
+
+	first := requests[0].Header.Get("Idempotency-Key")
+	second := requests[1].Header.Get("Idempotency-Key")
+	if first == "" || second == "" {
+		t.Fatalf("create requests sent Idempotency-Key %q and %q, expected keys", first, second)
+	}
+	if first == second {
+		t.Errorf("both create requests sent Idempotency-Key %q, expected a key per space", first)
+	}
+}
+
+func TestSpaceResourceClearSecrets(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/secretive/secrets", http.StatusOK, `{}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/secretive/secrets", http.StatusOK,
+		`{"API_KEY": {"key": "API_KEY"}, "DB_URL": {"key": "DB_URL"}}`)
+	api.handle(http.MethodDelete, "/api/spaces/testuser/secretive/secrets", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("secretive")
+	config["secrets"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"API_KEY": types.StringValue("s3cr3t"),
+		"DB_URL":  types.StringValue("postgres://db"),
+	})
+	space := createTestSpace(t, api, config)
+
+	// Unsetting secrets stops managing them, and leaves them in place.
+	config["secrets"] = types.MapNull(types.StringType)
+	requireNoErrors(t, "unset secrets", space.apply(config))
+
+	if requests := api.requestsTo(http.MethodDelete, "/api/spaces/testuser/secretive/secrets"); len(requests) != 0 {
+		t.Errorf("sent %d secret delete requests for unmanaged secrets", len(requests))
+	}
+
+	// An empty map manages the space to have no secrets at all.
+	config["secrets"] = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	requireNoErrors(t, "clear secrets", space.apply(config))
+
+	var deleted []string
+	for _, request := range api.requestsTo(http.MethodDelete, "/api/spaces/testuser/secretive/secrets") {
+		var secret struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal([]byte(request.Body), &secret); err != nil {
+			t.Fatal(err)
+		}
+		deleted = append(deleted, secret.Key)
+	}
+	sort.Strings(deleted)
+	if len(deleted) != 2 || deleted[0] != "API_KEY" || deleted[1] != "DB_URL" {
+		t.Errorf("deleted secrets %v, expected API_KEY and DB_URL", deleted)
+	}
+	if secrets := space.attribute("secrets"); secrets.IsNull() {
+		t.Error("secrets are null after clearing them, expected an empty map")
+	}
+}
+
+func TestSpaceResourceSleepTimeNotSupported(t *testing.T) {
+	api := newMockAPI(t)
+	space := newMockProvider(t, api).resource(testSpaceType)
+
+	config := testSpaceConfig("sleepy")
+	config["hardware"] = types.StringValue("cpu-basic")
+	_, _, diags := space.plan(config)
+	diag := findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Sleep Time Not Supported")
+	if diag == nil {
+		t.Fatalf("plan did not reject sleep_time on cpu-basic, got %v", diags)
+	}
+	if !diag.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("sleep_time")) {
+		t.Errorf("error is about %s, expected sleep_time", diag.Attribute)
+	}
+
+	config["hardware"] = types.StringValue("cpu-upgrade")
+	_, _, diags = space.plan(config)
+	requireNoErrors(t, "plan", diags)
+}
+
+func TestSpaceResourcePinned(t *testing.T) {
+	api := newMockAPI(t)
+	config := testSpaceConfig("pinned")
+	config["pinned"] = types.BoolValue(true)
+
+	// The mock keeps the pinned flag of the space as set through its
+	// settings.
+	pinned := false
+	api.handleFunc(http.MethodPut, "/api/spaces/testuser/pinned/settings", func(w http.ResponseWriter, r *http.Request) {
+		var settings struct {
+			Pinned *bool `json:"pinned"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil || settings.Pinned == nil {
+			http.Error(w, `{"error": "Invalid settings"}`, http.StatusBadRequest)
+			return
+		}
+		pinned = *settings.Pinned
+	})
+	servePinned := func() {
+		api.handleFunc(http.MethodGet, "/api/spaces/testuser/pinned", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "testuser/pinned", "pinned": pinned})
+		})
+	}
+
+	space := createTestSpace(t, api, config)
+	servePinned()
+
+	if !pinned {
+		t.Error("space was not pinned")
+	}
+
+	config["pinned"] = types.BoolValue(false)
+	requireNoErrors(t, "unpin", space.apply(config))
+
+	if pinned {
+		t.Error("space was not unpinned")
+	}
+
+	// A space pinned outside of Terraform is unpinned again.
+	pinned = true
+	requireNoErrors(t, "refresh", space.refresh())
+	if value := space.attribute("pinned"); !value.Equal(tftypes.NewValue(tftypes.Bool, true)) {
+		t.Fatalf("pinned = %s after pinning the space outside of Terraform, expected true", value)
+	}
+	requireNoErrors(t, "unpin", space.apply(config))
+	if pinned {
+		t.Error("space was not unpinned")
+	}
+}
+
+func TestSpaceResourcePinnedNotOwned(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPut, "/api/spaces/testuser/borrowed/settings", http.StatusForbidden, `{"error": "Forbidden"}`)
+
+	config := testSpaceConfig("borrowed")
+	config["pinned"] = types.BoolValue(true)
+
+	api.handle(http.MethodPost, "/api/repos/create", http.StatusOK, `{"name": "testuser/borrowed"}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/borrowed", http.StatusOK, `{"id": "testuser/borrowed"}`)
+	diags := newMockProvider(t, api).resource(testSpaceType).apply(config)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Space Not Owned") == nil {
+		t.Errorf("pinning a space that is not owned did not fail, got %v", diags)
+	}
+}
+
+func TestSpaceResourceAppPort(t *testing.T) {
+	api := newMockAPI(t)
+	space := newMockProvider(t, api).resource(testSpaceType)
+
+	config := testSpaceConfig("port")
+	config["app_port"] = types.Int64Value(8000)
+	_, _, diags := space.plan(config)
+	diag := findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Attribute Requires Another SDK")
+	if diag == nil {
+		t.Fatalf("plan accepted app_port on a gradio space, got %v", diags)
+	}
+	if !diag.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("app_port")) {
+		t.Errorf("error is about %s, expected app_port", diag.Attribute)
+	}
+
+	// Docker spaces accept it, with a warning to move it to the docker block.
+	config["sdk"] = types.StringValue("docker")
+	_, _, diags = space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, "Attribute Deprecated") == nil {
+		t.Errorf("plan did not warn that app_port is deprecated, got %v", diags)
+	}
+}
+
+func TestSpaceResourcePinnedInCard(t *testing.T) {
+	api := newMockAPI(t)
+	space := newMockProvider(t, api).resource(testSpaceType)
+
+	config := testSpaceConfig("pinned")
+	config["pinned"] = types.BoolValue(true)
+	config["card_metadata"] = types.ObjectValueMust(cardMetadataAttrTypes, map[string]attr.Value{
+		"title":          types.StringNull(),
+		"emoji":          types.StringNull(),
+		"color_from":     types.StringNull(),
+		"color_to":       types.StringNull(),
+		"pinned":         types.BoolValue(true),
+		"license":        types.StringNull(),
+		"app_file":       types.StringNull(),
+		"python_version": types.StringNull(),
+	})
+	_, _, diags := space.plan(config)
+	diag := findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Conflicting Pinned Settings")
+	if diag == nil {
+		t.Fatalf("plan accepted pinned along with card_metadata.pinned, got %v", diags)
+	}
+	if !diag.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("card_metadata").WithAttributeName("pinned")) {
+		t.Errorf("error is about %s, expected card_metadata.pinned", diag.Attribute)
+	}
+
+	// Either one alone is accepted.
+	delete(config, "pinned")
+	_, _, diags = space.plan(config)
+	requireNoErrors(t, "plan", diags)
+}
+
+func TestSpaceResourceCleanupSecrets(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/cleanup/secrets", http.StatusOK, `{}`)
+	api.handle(http.MethodPost, "/api/spaces/testuser/cleanup/variables", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("cleanup")
+	config["cleanup_secrets"] = types.BoolValue(true)
+	config["secrets"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"API_KEY": types.StringValue("s3cr3t"),
+		"DB_URL":  types.StringValue("postgres://db"),
+	})
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("gpt2"),
+	})
+	space := createTestSpace(t, api, config)
+
+	api.handle(http.MethodGet, "/api/spaces/testuser/cleanup/secrets", http.StatusOK,
+		`{"API_KEY": {"key": "API_KEY"}, "DB_URL": {"key": "DB_URL"}}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/cleanup/variables", http.StatusOK,
+		`{"MODEL": {"key": "MODEL", "value": "gpt2"}}`)
+	api.handle(http.MethodDelete, "/api/spaces/testuser/cleanup/variables", http.StatusOK, `{}`)
+	api.handle(http.MethodDelete, "/api/repos/delete", http.StatusOK, `{}`)
+
+	// The API refuses to delete one of the secrets.
+	api.handleFunc(http.MethodDelete, "/api/spaces/testuser/cleanup/secrets", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte(`"DB_URL"`)) {
+			http.Error(w, `{"error": "Secret is locked"}`, http.StatusForbidden)
+		}
+	})
+
+	diags := space.destroy()
+	requireNoErrors(t, "destroy", diags)
+
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, "Cleanup Error") == nil {
+		t.Errorf("destroy did not warn about the secret it failed to delete, got %v", diags)
+	}
+
+	// Secrets and variables are deleted before the space, which is deleted
+	// regardless of the secret that could not be.
+	var deleted []string
+	for _, request := range api.requestsTo(http.MethodDelete, "") {
+		var key struct {
+			Key string `json:"key"`
+		}
+		if request.Path != "/api/repos/delete" {
+			if err := json.Unmarshal([]byte(request.Body), &key); err != nil {
+				t.Fatal(err)
+			}
+		}
+		deleted = append(deleted, request.Path+" "+key.Key)
+	}
+	expected := []string{
+		"/api/spaces/testuser/cleanup/secrets API_KEY",
+		"/api/spaces/testuser/cleanup/secrets DB_URL",
+		"/api/spaces/testuser/cleanup/variables MODEL",
+		"/api/repos/delete ",
+	}
+	// Secrets are listed in no particular order.
+	if len(deleted) > 2 {
+		sort.Strings(deleted[:2])
+	}
+	if strings.Join(deleted, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("deleted %q, expected %q", deleted, expected)
+	}
+}
+
+func TestSpaceResourceStorageMigration(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/storage/storage", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("storage")
+	space := createTestSpace(t, api, config)
+
+	config["storage"] = types.StringValue("medium")
+	requireNoErrors(t, "update", space.apply(config))
+
+	// The space still has its previous storage while it migrates to the
+	// requested one.
+	api.handle(http.MethodGet, "/api/spaces/testuser/storage", http.StatusOK,
+		`{"id": "testuser/storage", "runtime": {"stage": "RUNNING", "storage": {"current": "small", "requested": "medium"}, "gcTimeout": 172800}}`)
+
+	if !space.planIsEmpty(config) {
+		t.Error("storage that is being migrated to shows a diff")
+	}
+	if storage := space.stringAttribute("storage"); storage != "medium" {
+		t.Errorf("storage = %q, expected medium", storage)
+	}
+	if current := space.stringAttribute("storage_current"); current != "small" {
+		t.Errorf("storage_current = %q, expected small", current)
+	}
+}
+
+func TestSpaceResourceSecretsManagedNonExclusively(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/shared/secrets", http.StatusOK, `{}`)
+	api.handle(http.MethodDelete, "/api/spaces/testuser/shared/secrets", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("shared")
+	config["manage_secrets_exclusively"] = types.BoolValue(false)
+	config["secrets"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"API_KEY": types.StringValue("s3cr3t"),
+	})
+	space := createTestSpace(t, api, config)
+
+	// A secret is added outside of Terraform.
+	api.handle(http.MethodGet, "/api/spaces/testuser/shared/secrets", http.StatusOK,
+		`{"API_KEY": {"key": "API_KEY"}, "EXTERNAL": {"key": "EXTERNAL"}}`)
+
+	config["secrets"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"API_KEY": types.StringValue("n3w-s3cr3t"),
+		"DB_URL":  types.StringValue("postgres://db"),
+	})
+	requireNoErrors(t, "update", space.apply(config))
+
+	if requests := api.requestsTo(http.MethodDelete, "/api/spaces/testuser/shared/secrets"); len(requests) != 0 {
+		t.Errorf("sent %d secret delete requests, expected none", len(requests))
+	}
+
+	var set []string
+	for _, request := range api.requestsTo(http.MethodPost, "/api/spaces/testuser/shared/secrets") {
+		var secret struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal([]byte(request.Body), &secret); err != nil {
+			t.Fatal(err)
+		}
+		set = append(set, secret.Key+"="+secret.Value)
+	}
+	// The secrets of the update are set in no particular order.
+	sort.Strings(set[1:])
+	expected := "API_KEY=s3cr3t,API_KEY=n3w-s3cr3t,DB_URL=postgres://db"
+	if strings.Join(set, ",") != expected {
+		t.Errorf("set secrets %v, expected %s", set, expected)
+	}
+}
+
+func TestSpaceResourceCustomDomain(t *testing.T) {
+	api := newMockAPI(t)
+
+	// The mock keeps the custom domain of the space as set through its
+	// settings.
+	domain := ""
+	api.handleFunc(http.MethodPut, "/api/spaces/testuser/domain/settings", func(w http.ResponseWriter, r *http.Request) {
+		var settings struct {
+			CustomDomain *string `json:"customDomain"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil || settings.CustomDomain == nil {
+			http.Error(w, `{"error": "Invalid settings"}`, http.StatusBadRequest)
+			return
+		}
+		domain = *settings.CustomDomain
+	})
+
+	config := testSpaceConfig("domain")
+	config["custom_domain"] = types.StringValue("demo.example.com")
+	space := createTestSpace(t, api, config)
+	api.handleFunc(http.MethodGet, "/api/spaces/testuser/domain", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "testuser/domain", "customDomain": domain})
+	})
+
+	if domain != "demo.example.com" {
+		t.Errorf("custom domain = %q, expected demo.example.com", domain)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	// A domain changed outside of Terraform is set back.
+	domain = "other.example.com"
+	if space.planIsEmpty(config) {
+		t.Fatal("custom domain changed outside of Terraform was not detected")
+	}
+	requireNoErrors(t, "update", space.apply(config))
+	if domain != "demo.example.com" {
+		t.Errorf("custom domain = %q, expected demo.example.com", domain)
+	}
+
+	// Domains the API rejects are reported on the attribute.
+	api.handle(http.MethodPut, "/api/spaces/testuser/domain/settings", http.StatusBadRequest,
+		`{"error": "Domain unverified.example.com is not verified"}`)
+
+	config["custom_domain"] = types.StringValue("unverified.example.com")
+	diags := space.apply(config)
+	rejected := findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Custom Domain Rejected")
+	if rejected == nil {
+		t.Fatalf("update did not report the rejected domain, got %v", diags)
+	}
+	if !strings.Contains(rejected.Detail, "is not verified") {
+		t.Errorf("error %q does not explain why the domain was rejected", rejected.Detail)
+	}
+
+	// Values that are not hostnames are rejected before reaching the API.
+	config["custom_domain"] = types.StringValue("https://demo.example.com/")
+	_, _, diags = space.plan(config)
+	if !hasErrors(diags) {
+		t.Error("plan accepted a URL as custom domain")
+	}
+}
+
+func TestSpaceResourceWaitForDeletion(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodDelete, "/api/repos/delete", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("lingering")
+	config["wait_for_deletion"] = types.BoolValue(true)
+	space := createTestSpace(t, api, config)
+
+	// The deleted space is still returned twice before it is gone.
+	lingering := 0
+	api.handleFunc(http.MethodGet, "/api/spaces/testuser/lingering", func(w http.ResponseWriter, r *http.Request) {
+		if lingering == 2 {
+			http.Error(w, `{"error": "Repository not found"}`, http.StatusNotFound)
+			return
+		}
+		lingering++
+		_, _ = io.WriteString(w, `{"id": "testuser/lingering"}`)
+	})
+
+	before := len(api.requestsTo(http.MethodGet, "/api/spaces/testuser/lingering"))
+	requireNoErrors(t, "destroy", space.destroy())
+
+	if gets := len(api.requestsTo(http.MethodGet, "/api/spaces/testuser/lingering")) - before; gets != 3 {
+		t.Errorf("sent %d requests for the deleted space, expected 3 until it was not found", gets)
+	}
+}
+
+func TestSpaceResourceTags(t *testing.T) {
+	api := newMockAPI(t)
+
+	config := testSpaceConfig("tagged")
+	config["tags"] = stringList("demo", "nlp")
+	space := createTestSpace(t, api, config)
+
+	creates := api.requestsTo(http.MethodPost, "/api/repos/create")
+	if len(creates) != 1 {
+		t.Fatalf("sent %d create requests, expected 1", len(creates))
+	}
+	var created struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(creates[0].Body), &created); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(created.Tags, ",") != "demo,nlp" {
+		t.Errorf("create request sent tags %q, expected demo and nlp", created.Tags)
+	}
+
+	// Tags the Hub adds by itself are not tracked.
+	api.handle(http.MethodGet, "/api/spaces/testuser/tagged", http.StatusOK,
+		`{"id": "testuser/tagged", "tags": ["gradio", "region:us", "demo", "nlp"]}`)
+
+	if !space.planIsEmpty(config) {
+		t.Error("tags added by the Hub show a diff")
+	}
+	if actual := space.listAttribute("tags"); strings.Join(actual, ",") != "demo,nlp" {
+		t.Errorf("tags = %q, expected demo and nlp", actual)
+	}
+
+	for name, invalid := range map[string]types.List{
+		"duplicate": stringList("demo", "demo"),
+		"empty":     stringList("demo", ""),
+	} {
+		config["tags"] = invalid
+		if _, _, diags := space.plan(config); !hasErrors(diags) {
+			t.Errorf("plan accepted %s tags", name)
+		}
+	}
+}
+
+func TestSpaceResourceLinkedRepos(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/linked/commit/main", http.StatusOK, `{}`)
+
+	// readme returns the card of the last commit, which the Hub serves back.
+	readme := func() string {
+		commits := api.requestsTo(http.MethodPost, "/api/spaces/testuser/linked/commit/main")
+		if len(commits) == 0 {
+			return ""
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(commits[len(commits)-1].Body))
+		for {
+			var line struct {
+				Key   string `json:"key"`
+				Value struct {
+					Path    string `json:"path"`
+					Content string `json:"content"`
+				} `json:"value"`
+			}
+			if err := decoder.Decode(&line); err != nil {
+				return ""
+			}
+			if line.Key == "file" && line.Value.Path == "README.md" {
+				content, _ := base64.StdEncoding.DecodeString(line.Value.Content)
+				return string(content)
+			}
+		}
+	}
+	api.handleFunc(http.MethodGet, "/spaces/testuser/linked/resolve/main/README.md", func(w http.ResponseWriter, r *http.Request) {
+		content := readme()
+		if content == "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, content)
+	})
+
+	config := testSpaceConfig("linked")
+	config["models"] = stringList("openai-community/gpt2")
+	config["datasets"] = stringList("stanfordnlp/imdb", "rajpurkar/squad")
+	space := createTestSpace(t, api, config)
+
+	expected := "---\nmodels:\n  - openai-community/gpt2\ndatasets:\n  - stanfordnlp/imdb\n  - rajpurkar/squad\n---\n"
+	if actual := readme(); actual != expected {
+		t.Errorf("create committed card %q, expected %q", actual, expected)
+	}
+
+	// The Hub derives the linked repositories from the card.
+	api.handle(http.MethodGet, "/api/spaces/testuser/linked", http.StatusOK,
+		`{"id": "testuser/linked", "models": ["openai-community/gpt2"], "datasets": ["stanfordnlp/imdb", "rajpurkar/squad"]}`)
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	config["models"] = stringList("openai-community/gpt2", "google-bert/bert-base-uncased")
+	config["datasets"] = stringList()
+	requireNoErrors(t, "update", space.apply(config))
+
+	expected = "---\nmodels:\n  - openai-community/gpt2\n  - google-bert/bert-base-uncased\ndatasets: []\n---\n"
+	if actual := readme(); actual != expected {
+		t.Errorf("update committed card %q, expected %q", actual, expected)
+	}
+
+	api.handle(http.MethodGet, "/api/spaces/testuser/linked", http.StatusOK,
+		`{"id": "testuser/linked", "models": ["openai-community/gpt2", "google-bert/bert-base-uncased"]}`)
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+	if models := space.listAttribute("models"); strings.Join(models, ",") != "openai-community/gpt2,google-bert/bert-base-uncased" {
+		t.Errorf("models = %q", models)
+	}
+}
+
+func TestSpaceResourceConfigure(t *testing.T) {
+	client := hfclient.New(hfclient.Config{HTTPClient: http.DefaultClient, Endpoint: "https://hub.example.com"})
+
+	r := &SpaceResource{}
+	var resp resource.ConfigureResponse
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &providerData{client: client},
+	}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configuring with provider data failed: %v", resp.Diagnostics)
+	}
+	if r.client != client {
+		t.Errorf("resource was configured with client %p, expected %p", r.client, client)
+	}
+
+	// Provider data used to be a bare HTTP client.
+	r = &SpaceResource{}
+	resp = resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: http.DefaultClient}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("configuring with an HTTP client did not fail")
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if !strings.Contains(detail, "Expected *providerData, got: *http.Client") {
+		t.Errorf("error %q does not name the expected and actual types", detail)
+	}
+}
+
+func TestSpaceResourceHardwareAlias(t *testing.T) {
+	api := newMockAPI(t)
+
+	config := testSpaceConfig("alias")
+	config["hardware"] = types.StringValue("A10G-Small")
+	space := createTestSpace(t, api, config)
+
+	creates := api.requestsTo(http.MethodPost, "/api/repos/create")
+	if len(creates) != 1 {
+		t.Fatalf("sent %d create requests, expected 1", len(creates))
+	}
+	var created struct {
+		Hardware string `json:"hardware"`
+	}
+	if err := json.Unmarshal([]byte(creates[0].Body), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Hardware != "a10g-small" {
+		t.Errorf("space was created on %q, expected a10g-small", created.Hardware)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("hardware alias shows a diff")
+	}
+
+	config["hardware"] = types.StringValue("Quantum XL")
+	if _, _, diags := space.plan(config); !hasErrors(diags) {
+		t.Error("plan accepted unknown hardware")
+	}
+}
+
+func TestSpaceResourceFromGit(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/imported/import", http.StatusOK, `{}`)
+
+	config := testSpaceConfig("imported")
+	delete(config, "template")
+	config["from_git"] = types.StringValue("https://github.com/gradio-app/hello-world.git")
+	space := createTestSpace(t, api, config)
+
+	imports := api.requestsTo(http.MethodPost, "/api/spaces/testuser/imported/import")
+	if len(imports) != 1 {
+		t.Fatalf("sent %d import requests, expected 1", len(imports))
+	}
+	if imports[0].Body != `{"url":"https://github.com/gradio-app/hello-world.git"}` {
+		t.Errorf("import request sent %s", imports[0].Body)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after create")
+	}
+
+	// Importing from another repository replaces the space.
+	config["from_git"] = types.StringValue("https://github.com/gradio-app/other.git")
+	_, requiresReplace, diags := space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 1 || !requiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("from_git")) {
+		t.Errorf("changing from_git requires replacing %v, expected from_git", requiresReplace)
+	}
+
+	config["template"] = types.StringValue("gradio-templates/chatbot")
+	if _, _, diags := space.plan(config); !hasErrors(diags) {
+		t.Error("plan accepted from_git along with a template")
+	}
+
+	// Hubs that cannot import from Git say so.
+	unsupported := testSpaceConfig("unsupported")
+	delete(unsupported, "template")
+	unsupported["from_git"] = types.StringValue("https://github.com/gradio-app/hello-world.git")
+	api.handle(http.MethodPost, "/api/repos/create", http.StatusOK, `{"name": "testuser/unsupported"}`)
+
+	diags = newMockProvider(t, api).resource(testSpaceType).apply(unsupported)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Importing From Git Unsupported") == nil {
+		t.Errorf("create did not report that importing is unsupported, got %v", diags)
+	}
+}
+
+func TestSpaceResourceErrorBodyTruncated(t *testing.T) {
+	api := newMockAPI(t)
+
+	config := testSpaceConfig("truncated")
+	api.handle(http.MethodPost, "/api/repos/create", http.StatusOK, `{"name": "testuser/truncated"}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/truncated", http.StatusOK, `{"id": "testuser/truncated"}`)
+
+	space := newMockProvider(t, api, map[string]attr.Value{
+		"max_error_body_bytes": types.Int64Value(64),
+	}).resource(testSpaceType)
+	requireNoErrors(t, "create", space.apply(config))
+
+	// The Hub fails with a large HTML error page.
+	page := "<html><body>" + strings.Repeat("<p>Bad Request</p>", 10000) + "</body></html>"
+	api.handleFunc(http.MethodPost, "/api/spaces/testuser/truncated/storage", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, page)
+	})
+
+	config["storage"] = types.StringValue("large")
+	diags := space.apply(config)
+	if !hasErrors(diags) {
+		t.Fatal("update did not fail")
+	}
+
+	var detail string
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			detail = diag.Detail
+		}
+	}
+	if !strings.Contains(detail, page[:64]) {
+		t.Errorf("error %q does not include the start of the response body", detail)
+	}
+	if strings.Contains(detail, page[:65]) {
+		t.Errorf("error includes more than 64 bytes of the response body: %q", detail)
+	}
+}
+
+func TestSpaceResourceRenameAndChangeHardware(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "/api/spaces/testuser/before/variables", http.StatusOK, `{}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/before/variables", http.StatusOK, `{"MODEL": {"value": "gpt2"}}`)
+
+	config := testSpaceConfig("before")
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("gpt2"),
+	})
+	space := createTestSpace(t, api, config)
+
+	api.handle(http.MethodPost, "/api/repos/move", http.StatusOK, `{}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/after", http.StatusOK, `{"id": "testuser/after", "author": "testuser"}`)
+	api.handle(http.MethodGet, "/api/spaces/testuser/after/runtime", http.StatusOK,
+		`{"stage": "RUNNING", "hardware": {"current": "cpu-upgrade", "requested": "cpu-upgrade"}}`)
+	api.handle(http.MethodPost, "/api/spaces/testuser/after/hardware", http.StatusOK, `{}`)
+	// The variables are moved along with the space, and only change once
+	// they are set under its new ID.
+	api.handleFunc(http.MethodGet, "/api/spaces/testuser/after/variables", func(w http.ResponseWriter, r *http.Request) {
+		value := "gpt2"
+		if len(api.requestsTo(http.MethodPost, "/api/spaces/testuser/after/variables")) != 0 {
+			value = "gpt2-large"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"MODEL": {"value": "`+value+`"}}`)
+	})
+	api.handle(http.MethodPost, "/api/spaces/testuser/after/variables", http.StatusOK, `{}`)
+	api.handle(http.MethodDelete, "/api/spaces/testuser/after/variables", http.StatusOK, `{}`)
+
+	config["name"] = types.StringValue("after")
+	config["hardware"] = types.StringValue("t4-small")
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("gpt2-large"),
+	})
+	_, requiresReplace, diags := space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Fatalf("renaming requires replacing %v", requiresReplace)
+	}
+
+	// toOldID counts the changes sent to the space under its previous ID.
+	toOldID := func() int {
+		count := 0
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+			for _, request := range api.requestsTo(method, "") {
+				if strings.HasPrefix(request.Path, "/api/spaces/testuser/before/") {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	before := toOldID()
+	requireNoErrors(t, "update", space.apply(config))
+
+	moves := api.requestsTo(http.MethodPost, "/api/repos/move")
+	if len(moves) != 1 || moves[0].Body != `{"fromRepo":"testuser/before","toRepo":"testuser/after","type":"space"}` {
+		t.Fatalf("sent move requests %+v", moves)
+	}
+	if requests := api.requestsTo(http.MethodPost, "/api/spaces/testuser/after/hardware"); len(requests) != 1 {
+		t.Errorf("sent %d hardware requests for the new ID, expected 1", len(requests))
+	}
+	if requests := api.requestsTo(http.MethodPost, "/api/spaces/testuser/after/variables"); len(requests) != 1 {
+		t.Errorf("sent %d variable requests for the new ID, expected 1", len(requests))
+	}
+	if sent := toOldID() - before; sent != 0 {
+		t.Errorf("sent %d changes to the previous ID of the space", sent)
+	}
+
+	if id := space.stringAttribute("id"); id != "testuser/after" {
+		t.Errorf("id = %q, expected testuser/after", id)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+}
+
+func TestSpaceResourceTemplateReplacement(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":     types.StringValue("template"),
+		"sdk":      types.StringValue("gradio"),
+		"hardware": types.StringValue("cpu-upgrade"),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	// A space without a template is updated in place.
+	config["hardware"] = types.StringValue("t4-small")
+	_, requiresReplace, diags := space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 0 {
+		t.Errorf("changing the hardware requires replacing %v", requiresReplace)
+	}
+
+	// Configuring a template forces a new space.
+	config["template"] = types.StringValue("gradio-templates/chatbot")
+	_, requiresReplace, diags = space.plan(config)
+	requireNoErrors(t, "plan", diags)
+	if len(requiresReplace) != 1 || !requiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("template")) {
+		t.Errorf("changing the template requires replacing %v, expected template", requiresReplace)
+	}
+}
+
+func TestSpaceResourceUnknownPrivateAndSleepTime(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":     types.StringValue("unknown"),
+		"sdk":      types.StringValue("gradio"),
+		"hardware": types.StringValue("cpu-upgrade"),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	// Attributes the configuration leaves to the Hub must not be sent as
+	// zero values.
+	creates := hub.Requests(http.MethodPost, "/api/repos/create")
+	if len(creates) != 1 {
+		t.Fatalf("sent %d create requests, expected 1", len(creates))
+	}
+	var created map[string]interface{}
+	if err := json.Unmarshal(creates[0].Body, &created); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"private", "sleepTime"} {
+		if value, ok := created[key]; ok {
+			t.Errorf("create request sent %s = %v", key, value)
+		}
+	}
+
+	// A space made private outside of Terraform stays private when private
+	// is not configured and something else changes.
+	existing := hub.Space("testutil/unknown")
+	existing.Private = true
+	hub.PutSpace(*existing)
+
+	config["hardware"] = types.StringValue("t4-small")
+	requireNoErrors(t, "update", space.apply(config))
+
+	if !hub.Space("testutil/unknown").Private {
+		t.Error("space was made public")
+	}
+	if settings := hub.Requests(http.MethodPut, "/api/spaces/testutil/unknown/settings"); len(settings) != 0 {
+		t.Errorf("sent %d settings requests, expected none", len(settings))
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+}
+
+func TestSpaceResourceSleepTimeDrift(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":       types.StringValue("sleepy"),
+		"sdk":        types.StringValue("gradio"),
+		"hardware":   types.StringValue("cpu-upgrade"),
+		"sleep_time": types.Int64Value(3600),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	// The sleep time is changed in the UI.
+	changed := hub.Space("testutil/sleepy")
+	sleepTime := int64(600)
+	changed.SleepTime = &sleepTime
+	hub.PutSpace(*changed)
+
+	if space.planIsEmpty(config) {
+		t.Fatal("sleep time changed outside of Terraform was not detected")
+	}
+	if actual := int64Value(t, space.state, "sleep_time"); actual != 600 {
+		t.Errorf("sleep_time = %d after refresh, expected 600", actual)
+	}
+
+	requireNoErrors(t, "apply", space.apply(config))
+	if actual := hub.Space("testutil/sleepy").SleepTime; actual == nil || *actual != 3600 {
+		t.Errorf("sleep time = %v, expected 3600", actual)
+	}
+
+	// A space that never sleeps is reported without a sleep time.
+	changed = hub.Space("testutil/sleepy")
+	changed.SleepTime = nil
+	hub.PutSpace(*changed)
+
+	requireNoErrors(t, "refresh", space.refresh())
+	if actual := int64Value(t, space.state, "sleep_time"); actual != sleepTimeNever {
+		t.Errorf("sleep_time = %d, expected %d", actual, sleepTimeNever)
+	}
+}
+
+func TestSpaceResourceUnconfiguredSleepTime(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":     types.StringValue("unconfigured"),
+		"sdk":      types.StringValue("gradio"),
+		"hardware": types.StringValue("cpu-upgrade"),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("gpt2"),
+	})
+	requireNoErrors(t, "update", space.apply(config))
+
+	if requests := hub.Requests(http.MethodPost, "/api/spaces/testutil/unconfigured/sleeptime"); len(requests) != 0 {
+		t.Errorf("sent %d sleep time requests for a space that does not configure one", len(requests))
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+}
+
+func TestSpaceResourceSleepTimeOnDefaultFreeHardware(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub, map[string]attr.Value{
+		"default_hardware": types.StringValue("cpu-basic"),
+	}).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name":       types.StringValue("free"),
+		"sdk":        types.StringValue("gradio"),
+		"sleep_time": types.Int64Value(3600),
+	}
+	_, _, diags := space.plan(config)
+	requireNoErrors(t, "plan", diags)
+
+	warning := findDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, "Sleep Time Ignored")
+	if warning == nil {
+		t.Fatalf("plan did not warn that sleep_time is ignored, got %v", diags)
+	}
+	if !warning.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("sleep_time")) {
+		t.Errorf("warning is about %s, expected sleep_time", warning.Attribute)
+	}
+
+	// Free hardware the configuration asks for is rejected outright.
+	config["hardware"] = types.StringValue("cpu-basic")
+	_, _, diags = space.plan(config)
+	if findDiagnostic(diags, tfprotov6.DiagnosticSeverityError, "Sleep Time Not Supported") == nil {
+		t.Errorf("plan did not reject sleep_time on configured free hardware, got %v", diags)
+	}
+}
+
+func TestSpaceResourceVariablesDiff(t *testing.T) {
+	hub := testutil.NewHub(t)
+	space := newHubProvider(t, hub).resource(testSpaceType)
+
+	config := map[string]attr.Value{
+		"name": types.StringValue("variables"),
+		"sdk":  types.StringValue("gradio"),
+		"variables": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"MODEL":       types.StringValue("gpt2"),
+			"TEMPERATURE": types.StringValue("0.7"),
+			"TOP_K":       types.StringValue("50"),
+		}),
+	}
+	requireNoErrors(t, "create", space.apply(config))
+
+	const variablesPath = "/api/spaces/testutil/variables/variables"
+	setBefore := len(hub.Requests(http.MethodPost, variablesPath))
+	deletedBefore := len(hub.Requests(http.MethodDelete, variablesPath))
+
+	config["variables"] = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL":       types.StringValue("gpt2"),
+		"TEMPERATURE": types.StringValue("0.2"),
+		"MAX_TOKENS":  types.StringValue("256"),
+	})
+	requireNoErrors(t, "update", space.apply(config))
+
+	// Only the variables that changed are written.
+	keys := func(requests []testutil.Request) []string {
+		var keys []string
+		for _, request := range requests {
+			var body struct {
+				Key string `json:"key"`
+			}
+			if err := json.Unmarshal(request.Body, &body); err != nil {
+				t.Fatal(err)
+			}
+			keys = append(keys, body.Key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	if set := keys(hub.Requests(http.MethodPost, variablesPath)[setBefore:]); strings.Join(set, ",") != "MAX_TOKENS,TEMPERATURE" {
+		t.Errorf("set variables %q, expected MAX_TOKENS and TEMPERATURE", set)
+	}
+	if deleted := keys(hub.Requests(http.MethodDelete, variablesPath)[deletedBefore:]); strings.Join(deleted, ",") != "TOP_K" {
+		t.Errorf("deleted variables %q, expected TOP_K", deleted)
+	}
+
+	variables := hub.Space("testutil/variables").Variables
+	if len(variables) != 3 || variables["TEMPERATURE"].Value != "0.2" || variables["MAX_TOKENS"].Value != "256" {
+		t.Errorf("space has variables %+v", variables)
+	}
+	if !space.planIsEmpty(config) {
+		t.Error("plan is not empty after update")
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpaceRuntimeDataSource{}
+
+// SpaceRuntimeDataSource defines the data source implementation.
+type SpaceRuntimeDataSource struct {
+	client *hfclient.Client
+}
+
+// SpaceRuntimeDataSourceModel describes the data source data model.
+type SpaceRuntimeDataSourceModel struct {
+	SpaceID           types.String `tfsdk:"space_id"`
+	Stage             types.String `tfsdk:"stage"`
+	HardwareCurrent   types.String `tfsdk:"hardware_current"`
+	HardwareRequested types.String `tfsdk:"hardware_requested"`
+	StorageCurrent    types.String `tfsdk:"storage_current"`
+	StorageRequested  types.String `tfsdk:"storage_requested"`
+	SleepTime         types.Int64  `tfsdk:"sleep_time"`
+	ErrorMessage      types.String `tfsdk:"error_message"`
+}
+
+func (d *SpaceRuntimeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_runtime"
+}
+
+func (d *SpaceRuntimeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the live runtime status of a space, for instance to assert in a `check` block that it is running.",
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "The stage of the space, such as `BUILDING`, `RUNNING`, `SLEEPING` or `RUNTIME_ERROR`.",
+				Computed:            true,
+			},
+			"hardware_current": schema.StringAttribute{
+				MarkdownDescription: "The hardware the space is running on, if any.",
+				Computed:            true,
+			},
+			"hardware_requested": schema.StringAttribute{
+				MarkdownDescription: "The hardware the space has requested, which differs from `hardware_current` while the hardware is changing.",
+				Computed:            true,
+			},
+			"storage_current": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier the space has, if any.",
+				Computed:            true,
+			},
+			"storage_requested": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier the space has requested.",
+				Computed:            true,
+			},
+			"sleep_time": schema.Int64Attribute{
+				MarkdownDescription: "Seconds of inactivity after which the space goes to sleep, or `-1` for a space that never sleeps.",
+				Computed:            true,
+			},
+			"error_message": schema.StringAttribute{
+				MarkdownDescription: "Why the space failed to build or start, if it did.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SpaceRuntimeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SpaceRuntimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpaceRuntimeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := d.client.GetSpaceRuntime(ctx, data.SpaceID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read space runtime", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Space Runtime Response: %+v", runtime))
+
+	data.Stage = types.StringValue(runtime.Stage)
+	data.HardwareCurrent = types.StringPointerValue(runtime.Hardware.Current)
+	data.HardwareRequested = types.StringPointerValue(runtime.Hardware.Requested)
+	data.StorageCurrent = types.StringPointerValue(runtime.Storage.Current)
+	data.StorageRequested = types.StringPointerValue(runtime.Storage.Requested)
+	data.ErrorMessage = types.StringPointerValue(runtime.ErrorMessage)
+
+	if runtime.SleepTime != nil {
+		data.SleepTime = types.Int64Value(*runtime.SleepTime)
+	} else {
+		data.SleepTime = types.Int64Value(sleepTimeNever)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpaceRuntimeDataSource() datasource.DataSource {
+	return &SpaceRuntimeDataSource{}
+}
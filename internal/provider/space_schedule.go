@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// defaultOffHoursHardware is the hardware of a scheduled space outside of
+// its window, unless configured otherwise.
+const defaultOffHoursHardware = "cpu-basic"
+
+// scheduleDays lists the days a schedule can be restricted to, in the order
+// of time.Weekday.
+var scheduleDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// SpaceScheduleModel describes the schedule block.
+type SpaceScheduleModel struct {
+	StartHour        types.Int64  `tfsdk:"start_hour"`
+	EndHour          types.Int64  `tfsdk:"end_hour"`
+	Days             types.List   `tfsdk:"days"`
+	Timezone         types.String `tfsdk:"timezone"`
+	OffHoursHardware types.String `tfsdk:"off_hours_hardware"`
+}
+
+// spaceScheduleBlock returns the schedule block of the space resource.
+func spaceScheduleBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Runs the space on `hardware` during a daily window only, and on `off_hours_hardware` outside of it, to save costs on demos that are only used during business hours. The provider can only change the hardware while Terraform runs, so the window is enforced by applying the configuration regularly, such as every hour from a scheduled CI job.",
+		Attributes: map[string]schema.Attribute{
+			"start_hour": schema.Int64Attribute{
+				MarkdownDescription: "The hour the window starts at, from 0 to 23. Required when the block is set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 23),
+				},
+			},
+			"end_hour": schema.Int64Attribute{
+				MarkdownDescription: "The hour the window ends at, from 0 to 23. Windows ending before they start span midnight. Required when the block is set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 23),
+				},
+			},
+			"days": schema.ListAttribute{
+				MarkdownDescription: "The days the window applies on, among `" + strings.Join(scheduleDays, "`, `") + "`. Defaults to every day.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(scheduleDays...)),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "The IANA time zone of the window, such as `Europe/Paris`. Defaults to `UTC`.",
+				Optional:            true,
+			},
+			"off_hours_hardware": schema.StringAttribute{
+				MarkdownDescription: "The hardware flavor of the space outside of the window. Defaults to `" + defaultOffHoursHardware + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					hardwareValidator{},
+				},
+			},
+		},
+	}
+}
+
+// spaceSchedule returns the schedule block, or nil if it is not set.
+func spaceSchedule(ctx context.Context, block types.Object, diags *diag.Diagnostics) *SpaceScheduleModel {
+	if block.IsNull() || block.IsUnknown() {
+		return nil
+	}
+
+	var schedule SpaceScheduleModel
+	diags.Append(block.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &schedule
+}
+
+// validate checks the attributes of the schedule that the schema cannot.
+func (s *SpaceScheduleModel) validate(diags *diag.Diagnostics) {
+	for name, hour := range map[string]types.Int64{"start_hour": s.StartHour, "end_hour": s.EndHour} {
+		if hour.IsNull() {
+			diags.AddAttributeError(
+				path.Root("schedule").AtName(name),
+				"Missing Schedule Hour",
+				fmt.Sprintf("The schedule block requires %s.", name),
+			)
+		}
+	}
+
+	if !s.StartHour.IsNull() && !s.StartHour.IsUnknown() && s.StartHour.Equal(s.EndHour) {
+		diags.AddAttributeError(
+			path.Root("schedule").AtName("end_hour"),
+			"Empty Schedule Window",
+			"The window of the schedule must end at a different hour than it starts.",
+		)
+	}
+
+	if !s.Timezone.IsNull() && !s.Timezone.IsUnknown() {
+		if _, err := time.LoadLocation(s.Timezone.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("schedule").AtName("timezone"),
+				"Invalid Time Zone",
+				fmt.Sprintf("Time zone %q is not a valid IANA time zone, got error: %s", s.Timezone.ValueString(), err),
+			)
+		}
+	}
+}
+
+// active reports whether now falls within the window of the schedule.
+func (s *SpaceScheduleModel) active(ctx context.Context, now time.Time, diags *diag.Diagnostics) bool {
+	location := time.UTC
+	if !s.Timezone.IsNull() {
+		var err error
+		if location, err = time.LoadLocation(s.Timezone.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("schedule").AtName("timezone"), "Invalid Time Zone", err.Error())
+			return false
+		}
+	}
+	now = now.In(location)
+
+	if !s.Days.IsNull() {
+		var days []string
+		diags.Append(s.Days.ElementsAs(ctx, &days, false)...)
+
+		today := scheduleDays[now.Weekday()]
+		found := false
+		for _, day := range days {
+			found = found || day == today
+		}
+		if !found {
+			return false
+		}
+	}
+
+	hour := int64(now.Hour())
+	start, end := s.StartHour.ValueInt64(), s.EndHour.ValueInt64()
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// scheduledHardware returns the hardware a space on hardware should run on
+// at now according to schedule, in its canonical spelling.
+func scheduledHardware(ctx context.Context, schedule *SpaceScheduleModel, hardware types.String, now time.Time, diags *diag.Diagnostics) types.String {
+	if schedule.active(ctx, now, diags) {
+		return types.StringValue(canonicalHardware(hardware.ValueString()))
+	}
+
+	if schedule.OffHoursHardware.IsNull() {
+		return types.StringValue(defaultOffHoursHardware)
+	}
+	return types.StringValue(canonicalHardware(schedule.OffHoursHardware.ValueString()))
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// staticIndexFile is the page static spaces are served from, relative to
+// their output directory.
+const staticIndexFile = "index.html"
+
+// absolutePathRegexp matches URL paths starting with a slash.
+var absolutePathRegexp = regexp.MustCompile(`^/`)
+
+// SpaceDockerModel describes the docker block.
+type SpaceDockerModel struct {
+	AppPort  types.Int64  `tfsdk:"app_port"`
+	BasePath types.String `tfsdk:"base_path"`
+}
+
+// dockerAttrTypes are the attribute types of the docker object.
+var dockerAttrTypes = map[string]attr.Type{
+	"app_port":  types.Int64Type,
+	"base_path": types.StringType,
+}
+
+// SpaceStaticModel describes the static block.
+type SpaceStaticModel struct {
+	BuildCommand types.String `tfsdk:"build_command"`
+	OutputDir    types.String `tfsdk:"output_dir"`
+}
+
+// staticAttrTypes are the attribute types of the static object.
+var staticAttrTypes = map[string]attr.Type{
+	"build_command": types.StringType,
+	"output_dir":    types.StringType,
+}
+
+// spaceDockerBlock returns the docker block of the space resource.
+func spaceDockerBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Settings of Docker spaces, written into the YAML frontmatter of the `README.md` of the space. Only valid when `sdk` is `docker`. Docker spaces are always built from the `Dockerfile` at the root of the repository.",
+		Attributes: map[string]schema.Attribute{
+			"app_port": schema.Int64Attribute{
+				MarkdownDescription: "The port the container serves its app on. The Hub defaults to 7860.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"base_path": schema.StringAttribute{
+				MarkdownDescription: "The path the space opens on, such as `/docs`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(absolutePathRegexp, "must start with a slash"),
+				},
+			},
+		},
+	}
+}
+
+// spaceStaticBlock returns the static block of the space resource.
+func spaceStaticBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Settings of static spaces, written into the YAML frontmatter of the `README.md` of the space. Only valid when `sdk` is `static`.",
+		Attributes: map[string]schema.Attribute{
+			"build_command": schema.StringAttribute{
+				MarkdownDescription: "The command building the site, such as `npm run build`. Spaces without one are served as they are committed.",
+				Optional:            true,
+			},
+			"output_dir": schema.StringAttribute{
+				MarkdownDescription: "The directory holding the `" + staticIndexFile + "` of the built site, such as `dist`. Written as the `app_file` of the space, so it cannot be combined with `card_metadata.app_file`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// spaceDocker returns the docker block, or nil if it is not set.
+func spaceDocker(ctx context.Context, block types.Object, diags *diag.Diagnostics) *SpaceDockerModel {
+	if block.IsNull() || block.IsUnknown() {
+		return nil
+	}
+
+	var docker SpaceDockerModel
+	diags.Append(block.As(ctx, &docker, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &docker
+}
+
+// spaceStatic returns the static block, or nil if it is not set.
+func spaceStatic(ctx context.Context, block types.Object, diags *diag.Diagnostics) *SpaceStaticModel {
+	if block.IsNull() || block.IsUnknown() {
+		return nil
+	}
+
+	var static SpaceStaticModel
+	diags.Append(block.As(ctx, &static, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &static
+}
+
+// validateSpaceSDKBlocks checks that the docker and static blocks and the
+// app_port attribute of data are only set for spaces using their SDK, and do
+// not conflict with the attributes writing the same keys of the card.
+func validateSpaceSDKBlocks(ctx context.Context, data *SpaceResourceModel, diags *diag.Diagnostics) {
+	docker := spaceDocker(ctx, data.Docker, diags)
+	static := spaceStatic(ctx, data.Static, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if !data.SDK.IsNull() && !data.SDK.IsUnknown() {
+		sdk := data.SDK.ValueString()
+		for name, set := range map[string]bool{"docker": docker != nil, "static": static != nil} {
+			if set && sdk != name {
+				diags.AddAttributeError(
+					path.Root(name),
+					"Block Requires Another SDK",
+					fmt.Sprintf("The %s block only applies to spaces using the %s SDK, this space uses the %s SDK.", name, name, sdk),
+				)
+			}
+		}
+		if !data.AppPort.IsNull() && sdk != "docker" {
+			diags.AddAttributeError(
+				path.Root("app_port"),
+				"Attribute Requires Another SDK",
+				fmt.Sprintf("The app_port attribute only applies to spaces using the docker SDK, this space uses the %s SDK.", sdk),
+			)
+		}
+	}
+
+	if docker != nil && !docker.AppPort.IsNull() && !data.AppPort.IsNull() {
+		diags.AddAttributeError(
+			path.Root("docker").AtName("app_port"),
+			"Conflicting App Port",
+			"The app port is set both in app_port and in the docker block, remove app_port.",
+		)
+	}
+
+	if static != nil && !static.OutputDir.IsNull() {
+		metadata := cardMetadata(ctx, data.CardMetadata, diags)
+		if metadata != nil && !metadata.AppFile.IsNull() {
+			diags.AddAttributeError(
+				path.Root("static").AtName("output_dir"),
+				"Conflicting App File",
+				"The output directory of a static space is written as its app_file, which is also set in card_metadata. Remove one of them.",
+			)
+		}
+	}
+}
+
+// writeCardDocker sets the attributes of docker that are set in card.
+func writeCardDocker(card *repoCard, docker *SpaceDockerModel) {
+	if !docker.AppPort.IsNull() {
+		card.set("app_port", docker.AppPort.ValueInt64())
+	}
+	if !docker.BasePath.IsNull() {
+		card.set("base_path", docker.BasePath.ValueString())
+	}
+}
+
+// readCardDocker refreshes the attributes of docker that are set from card.
+func readCardDocker(card *repoCard, docker *SpaceDockerModel) {
+	if !docker.AppPort.IsNull() {
+		docker.AppPort = types.Int64Null()
+
+		var port int64
+		if node := card.get("app_port"); node != nil && node.Decode(&port) == nil {
+			docker.AppPort = types.Int64Value(port)
+		}
+	}
+
+	if !docker.BasePath.IsNull() {
+		docker.BasePath = types.StringNull()
+		if node := card.get("base_path"); node != nil {
+			docker.BasePath = types.StringValue(node.Value)
+		}
+	}
+}
+
+// writeCardStatic sets the attributes of static that are set in card.
+func writeCardStatic(card *repoCard, static *SpaceStaticModel) {
+	if !static.BuildCommand.IsNull() {
+		card.set("app_build_command", static.BuildCommand.ValueString())
+	}
+	if !static.OutputDir.IsNull() {
+		card.set("app_file", pathpkg.Join(static.OutputDir.ValueString(), staticIndexFile))
+	}
+}
+
+// readCardStatic refreshes the attributes of static that are set from card.
+func readCardStatic(card *repoCard, static *SpaceStaticModel) {
+	if !static.BuildCommand.IsNull() {
+		static.BuildCommand = types.StringNull()
+		if node := card.get("app_build_command"); node != nil {
+			static.BuildCommand = types.StringValue(node.Value)
+		}
+	}
+
+	if !static.OutputDir.IsNull() {
+		// Output directories are compared in the form they are configured
+		// in, such as with a trailing slash, as long as they point at the
+		// same app file.
+		configured := static.OutputDir.ValueString()
+		static.OutputDir = types.StringNull()
+
+		if node := card.get("app_file"); node != nil && strings.HasSuffix(node.Value, staticIndexFile) {
+			dir := pathpkg.Dir(node.Value)
+			if pathpkg.Clean(configured) == dir {
+				dir = configured
+			}
+			static.OutputDir = types.StringValue(dir)
+		}
+	}
+}
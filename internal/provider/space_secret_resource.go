@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceSecretResource{}
+	_ resource.ResourceWithConfigure   = &SpaceSecretResource{}
+	_ resource.ResourceWithImportState = &SpaceSecretResource{}
+)
+
+// SpaceSecretResource defines the resource implementation.
+type SpaceSecretResource struct {
+	client *hfclient.Client
+}
+
+// SpaceSecretResourceModel describes the resource data model.
+type SpaceSecretResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SpaceID     types.String `tfsdk:"space_id"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+
+	ValueWO        types.String `tfsdk:"value_wo"`
+	ValueWOVersion types.Int64  `tfsdk:"value_wo_version"`
+}
+
+func (r *SpaceSecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_secret"
+}
+
+func (r *SpaceSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single secret of a space. Spaces whose secrets are partly managed by this resource should set `manage_secrets_exclusively = false` or leave `secrets` unset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the secret, in the form `namespace/name/KEY`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`. Changing this forces a new secret to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The name of the secret. Changing this forces a new secret to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the secret. The API never returns secret values, so changes made outside of Terraform are not detected. Exactly one of `value` and `value_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: "The value of the secret, sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. As Terraform cannot detect changes to write-only values, the value is only sent again when `value_wo_version` changes.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version of `value_wo`. Change it whenever the value changes so that it is sent to the API again.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the secret.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *SpaceSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SpaceSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value := secretValue(ctx, data, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Adding secret %s to space %s", data.Key.ValueString(), data.SpaceID.ValueString()))
+
+	err := r.client.SetSpaceSecret(ctx, data.SpaceID.ValueString(), data.Key.ValueString(), value, data.Description.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("add secret %s", data.Key.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(spaceKeyID(data.SpaceID.ValueString(), data.Key.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets, err := r.client.ListSpaceSecrets(ctx, data.SpaceID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s not found, removing secret %s from state", data.SpaceID.ValueString(), data.Key.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read secrets", err)
+		return
+	}
+
+	secret, ok := secrets[data.Key.ValueString()]
+	if !ok {
+		tflog.Debug(ctx, fmt.Sprintf("Secret %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !data.Description.IsNull() || secret.Description != "" {
+		data.Description = types.StringValue(secret.Description)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value := secretValue(ctx, data, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Setting a secret that already exists overwrites it.
+	tflog.Debug(ctx, fmt.Sprintf("Updating secret %s of space %s", data.Key.ValueString(), data.SpaceID.ValueString()))
+
+	err := r.client.SetSpaceSecret(ctx, data.SpaceID.ValueString(), data.Key.ValueString(), value, data.Description.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("update secret %s", data.Key.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A secret whose space is already gone is as good as deleted.
+	err := r.client.DeleteSpaceSecret(ctx, data.SpaceID.ValueString(), data.Key.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete secret %s", data.Key.ValueString()), err)
+		return
+	}
+}
+
+func (r *SpaceSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	spaceID, key, ok := splitSpaceKeyID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name/KEY, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), spaceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+// secretValue returns the value of the secret planned in data, which is
+// taken from config if it is write-only.
+func secretValue(ctx context.Context, data *SpaceSecretResourceModel, config tfsdk.Config, diags *diag.Diagnostics) string {
+	if !data.Value.IsNull() {
+		return data.Value.ValueString()
+	}
+
+	var value types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("value_wo"), &value)...)
+
+	return value.ValueString()
+}
+
+func NewSpaceSecretResource() resource.Resource {
+	return &SpaceSecretResource{}
+}
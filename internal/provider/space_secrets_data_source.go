@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpaceSecretsDataSource{}
+
+// SpaceSecretsDataSource defines the data source implementation.
+type SpaceSecretsDataSource struct {
+	client *hfclient.Client
+}
+
+// SpaceSecretsDataSourceModel describes the data source data model.
+type SpaceSecretsDataSourceModel struct {
+	SpaceID      types.String `tfsdk:"space_id"`
+	RequiredKeys types.List   `tfsdk:"required_keys"`
+	Keys         types.List   `tfsdk:"keys"`
+}
+
+func (d *SpaceSecretsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_secrets"
+}
+
+func (d *SpaceSecretsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the keys of the secrets of a space. Secret values are never returned by the API.",
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"required_keys": schema.ListAttribute{
+				MarkdownDescription: "Keys of secrets the space needs to run. Reading the data source fails, listing the missing keys, if any of them is not set on the space.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"keys": schema.ListAttribute{
+				MarkdownDescription: "The keys of the secrets of the space, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SpaceSecretsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SpaceSecretsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpaceSecretsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets, err := d.client.ListSpaceSecrets(ctx, data.SpaceID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list space secrets", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Space %s has %d secrets", data.SpaceID.ValueString(), len(secrets)))
+
+	if !data.RequiredKeys.IsNull() {
+		var required []string
+		resp.Diagnostics.Append(data.RequiredKeys.ElementsAs(ctx, &required, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var missing []string
+		for _, key := range required {
+			if _, ok := secrets[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("required_keys"),
+				"Missing Space Secrets",
+				fmt.Sprintf("Space %s is missing the required secrets %s. Set them in the settings of the space or with the secrets attribute of its resource.", data.SpaceID.ValueString(), strings.Join(missing, ", ")),
+			)
+			return
+		}
+	}
+
+	keys, diags := stringListValue(ctx, sortedKeys(secrets))
+	resp.Diagnostics.Append(diags...)
+	data.Keys = keys
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpaceSecretsDataSource() datasource.DataSource {
+	return &SpaceSecretsDataSource{}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// spaceSleepTimeValidator validates that the sleep time of a space is one the
+// Hub accepts on its hardware: free hardware rejects any custom sleep time
+// with a 400, and other hardware takes -1 or a positive number of seconds.
+type spaceSleepTimeValidator struct{}
+
+var _ resource.ConfigValidator = spaceSleepTimeValidator{}
+
+func (v spaceSleepTimeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("sleep_time must be %d or a positive number of seconds, and cannot be set on free hardware (%s)", sleepTimeNever, strings.Join(sortedKeys(sleepTimeIgnoredHardware), ", "))
+}
+
+func (v spaceSleepTimeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v spaceSleepTimeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sleepTime types.Int64
+	var hardware types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sleep_time"), &sleepTime)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hardware"), &hardware)...)
+
+	if resp.Diagnostics.HasError() || sleepTime.IsNull() || sleepTime.IsUnknown() {
+		return
+	}
+
+	if seconds := sleepTime.ValueInt64(); seconds != sleepTimeNever && seconds <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sleep_time"),
+			"Invalid Sleep Time",
+			fmt.Sprintf("Sleep time %d is not valid, expected %d for a space that never sleeps or a positive number of seconds.", seconds, sleepTimeNever),
+		)
+		return
+	}
+
+	// Spaces that leave the hardware unset, or compute it, are checked once
+	// the hardware is planned.
+	if hardware.IsNull() || hardware.IsUnknown() {
+		return
+	}
+
+	if sleepTimeIgnoredHardware[canonicalHardware(hardware.ValueString())] {
+		addSleepTimeHardwareError(&resp.Diagnostics, hardware.ValueString())
+	}
+}
+
+// addSleepTimeHardwareError reports that a sleep time is configured for a
+// space on hardware, which does not support one.
+func addSleepTimeHardwareError(diags *diag.Diagnostics, hardware string) {
+	diags.AddAttributeError(
+		path.Root("sleep_time"),
+		"Sleep Time Not Supported",
+		fmt.Sprintf("Hardware %q is free and always uses the sleep time of the Hub, which rejects a custom one. "+
+			"Remove sleep_time, or request upgraded hardware such as cpu-upgrade, on which sleep_time may be %d or a positive number of seconds.", hardware, sleepTimeNever),
+	)
+}
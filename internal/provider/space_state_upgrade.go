@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// spaceSchemaVersion is the version of the schema of the space resource. It
+// is incremented, along with a new upgrader in UpgradeState, whenever states
+// written by previous versions of the provider need to be migrated.
+const spaceSchemaVersion = 1
+
+func (r *SpaceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeSpaceStateV0,
+		},
+	}
+}
+
+// upgradeSpaceStateV0 migrates states whose id is the bare name of the space
+// to the namespace/name form, and fills in the namespace from the id where it
+// is missing. Attributes added since version 0 are read as null.
+func upgradeSpaceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	raw, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+			IgnoreUndefinedAttributes: true,
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Space State",
+			fmt.Sprintf("Unable to read the state of the space written by a previous version of the provider, got error: %s", err),
+		)
+		return
+	}
+	resp.State.Raw = raw
+
+	var id, namespace types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	if resp.Diagnostics.HasError() || id.IsNull() {
+		return
+	}
+
+	owner, _, found := strings.Cut(id.ValueString(), "/")
+	switch {
+	case !found && namespace.ValueString() != "":
+		upgraded := namespace.ValueString() + "/" + id.ValueString()
+		tflog.Debug(ctx, fmt.Sprintf("Upgrading space ID %s to %s", id.ValueString(), upgraded))
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), upgraded)...)
+	case found && namespace.ValueString() == "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), owner)...)
+	}
+}
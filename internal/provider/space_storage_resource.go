@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceStorageResource{}
+	_ resource.ResourceWithConfigure   = &SpaceStorageResource{}
+	_ resource.ResourceWithImportState = &SpaceStorageResource{}
+	_ resource.ResourceWithModifyPlan  = &SpaceStorageResource{}
+)
+
+// SpaceStorageResource defines the resource implementation.
+type SpaceStorageResource struct {
+	client *hfclient.Client
+}
+
+// SpaceStorageResourceModel describes the resource data model.
+type SpaceStorageResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SpaceID       types.String `tfsdk:"space_id"`
+	Tier          types.String `tfsdk:"tier"`
+	TierCurrent   types.String `tfsdk:"tier_current"`
+	WipeOnDestroy types.Bool   `tfsdk:"wipe_on_destroy"`
+}
+
+func (r *SpaceStorageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_storage"
+}
+
+func (r *SpaceStorageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the persistent storage of a space independently of the space, so that it can outlive the resource managing it. Spaces whose storage is managed by this resource should leave `storage` unset and ignore changes to it with `lifecycle { ignore_changes = [storage] }`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`. Changing this forces new storage to be requested.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tier": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier, one of `" + strings.Join(storageTiers, "`, `") + "`. Storage can only grow: moving to a smaller tier deletes the storage and all its data, which requires `wipe_on_destroy`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(storageTiers...),
+				},
+			},
+			"tier_current": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier the space currently has, which lags behind `tier` while a storage change is in progress.",
+				Computed:            true,
+			},
+			"wipe_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the resource deletes the persistent storage and all the data stored on it. When `false`, destroying the resource only removes it from the Terraform state and the storage is retained. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *SpaceStorageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SpaceStorageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the storage is requested or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan SpaceStorageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !storageDeletion(state.Tier, plan.Tier) {
+		return
+	}
+
+	if !plan.WipeOnDestroy.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tier"),
+			"Storage Deletion Not Allowed",
+			fmt.Sprintf("Changing the storage of space %s from %q to %q deletes its persistent storage and all the data stored on it. Set wipe_on_destroy = true to allow this.", state.SpaceID.ValueString(), state.Tier.ValueString(), plan.Tier.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("tier"),
+		"Persistent Storage Will Be Deleted",
+		fmt.Sprintf("The persistent storage of space %s and all the data stored on it will be deleted.", state.SpaceID.ValueString()),
+	)
+}
+
+func (r *SpaceStorageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceStorageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Requesting %s storage for space %s", data.Tier.ValueString(), data.SpaceID.ValueString()))
+
+	if err := r.client.SetSpaceStorage(ctx, data.SpaceID.ValueString(), data.Tier.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "request space storage", err)
+		return
+	}
+
+	data.ID = data.SpaceID
+	r.readTierCurrent(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceStorageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceStorageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := r.client.GetSpaceRuntime(ctx, data.SpaceID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s not found, removing storage from state", data.SpaceID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read space runtime", err)
+		return
+	}
+
+	if runtime.Storage.Requested == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s has no persistent storage, removing it from state", data.SpaceID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Tier = types.StringValue(*runtime.Storage.Requested)
+	data.TierCurrent = types.StringPointerValue(runtime.Storage.Current)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceStorageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *SpaceStorageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Tier.Equal(state.Tier) {
+		// A smaller tier can only be requested once the storage is gone.
+		if storageDeletion(state.Tier, data.Tier) {
+			tflog.Debug(ctx, fmt.Sprintf("Deleting persistent storage of space %s", data.SpaceID.ValueString()))
+
+			if err := r.client.DeleteSpaceStorage(ctx, data.SpaceID.ValueString()); err != nil && !hfclient.IsNotFound(err) {
+				addClientError(&resp.Diagnostics, "delete space storage", err)
+				return
+			}
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Requesting %s storage for space %s", data.Tier.ValueString(), data.SpaceID.ValueString()))
+
+		if err := r.client.SetSpaceStorage(ctx, data.SpaceID.ValueString(), data.Tier.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, "update space storage", err)
+			return
+		}
+	}
+
+	r.readTierCurrent(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceStorageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceStorageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.WipeOnDestroy.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Persistent Storage Retained",
+			fmt.Sprintf("The persistent storage of space %s was removed from the Terraform state but not deleted. Set wipe_on_destroy = true to delete it along with the resource.", data.SpaceID.ValueString()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Deleting persistent storage of space %s", data.SpaceID.ValueString()))
+
+	// Storage whose space is already gone is as good as deleted.
+	err := r.client.DeleteSpaceStorage(ctx, data.SpaceID.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete space storage", err)
+		return
+	}
+}
+
+func (r *SpaceStorageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !repoIDRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wipe_on_destroy"), false)...)
+}
+
+// readTierCurrent refreshes the tier the space currently has, which is null
+// while the storage is being provisioned.
+func (r *SpaceStorageResource) readTierCurrent(ctx context.Context, data *SpaceStorageResourceModel, diags *diag.Diagnostics) {
+	runtime, err := r.client.GetSpaceRuntime(ctx, data.SpaceID.ValueString())
+	if err != nil {
+		addClientError(diags, "read space runtime", err)
+		return
+	}
+
+	data.TierCurrent = types.StringPointerValue(runtime.Storage.Current)
+}
+
+func NewSpaceStorageResource() resource.Resource {
+	return &SpaceStorageResource{}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// maxSubdomainLength is the length of the longest DNS label, above which the
+// Hub shortens subdomains in a way that cannot be derived from the space ID.
+const maxSubdomainLength = 63
+
+// subdomainInvalidRegexp matches the characters of a space ID that are
+// replaced by dashes in its subdomain.
+var subdomainInvalidRegexp = regexp.MustCompile(`[^a-z0-9]`)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &SpaceURLFunction{}
+
+// SpaceURLFunction defines the function implementation.
+type SpaceURLFunction struct{}
+
+func (f *SpaceURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "space_url"
+}
+
+func (f *SpaceURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the URL a space is served from.",
+		MarkdownDescription: "Returns the `https://<subdomain>.hf.space` URL a space is served from, without calling the API. The subdomain is the space ID in lower case, with every character other than letters and digits replaced by a dash. IDs whose subdomain would be longer than 63 characters are rejected, as the Hub shortens those; read the `url` attribute of the space instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "space_id",
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SpaceURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var spaceID string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &spaceID))
+	if resp.Error != nil {
+		return
+	}
+
+	if !repoIDRegexp.MatchString(spaceID) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Expected a space ID of the form namespace/name, got: %q", spaceID))
+		return
+	}
+
+	subdomain := spaceSubdomain(spaceID)
+	if len(subdomain) > maxSubdomainLength {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The subdomain of space %s is longer than %d characters and is shortened by the Hub, read the url attribute of the space instead.", spaceID, maxSubdomainLength))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, "https://"+subdomain+".hf.space"))
+}
+
+// spaceSubdomain returns the hf.space subdomain the Hub serves the space
+// spaceID from, as long as it fits in a DNS label.
+func spaceSubdomain(spaceID string) string {
+	return subdomainInvalidRegexp.ReplaceAllString(strings.ToLower(spaceID), "-")
+}
+
+func NewSpaceURLFunction() function.Function {
+	return &SpaceURLFunction{}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceVariableResource{}
+	_ resource.ResourceWithConfigure   = &SpaceVariableResource{}
+	_ resource.ResourceWithImportState = &SpaceVariableResource{}
+)
+
+// SpaceVariableResource defines the resource implementation.
+type SpaceVariableResource struct {
+	client *hfclient.Client
+}
+
+// SpaceVariableResourceModel describes the resource data model.
+type SpaceVariableResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SpaceID     types.String `tfsdk:"space_id"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *SpaceVariableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_variable"
+}
+
+func (r *SpaceVariableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single variable of a space. Spaces whose variables are managed by this resource should leave `variables` unset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the variable, in the form `namespace/name/KEY`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`. Changing this forces a new variable to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The name of the variable. Changing this forces a new variable to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the variable.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the variable.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *SpaceVariableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SpaceVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceVariableResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Adding variable %s to space %s", data.Key.ValueString(), data.SpaceID.ValueString()))
+
+	err := r.client.SetSpaceVariable(ctx, data.SpaceID.ValueString(), data.Key.ValueString(), data.Value.ValueString(), data.Description.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("add variable %s", data.Key.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(spaceKeyID(data.SpaceID.ValueString(), data.Key.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceVariableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, err := r.client.ListSpaceVariables(ctx, data.SpaceID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Space %s not found, removing variable %s from state", data.SpaceID.ValueString(), data.Key.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read variables", err)
+		return
+	}
+
+	variable, ok := variables[data.Key.ValueString()]
+	if !ok {
+		tflog.Debug(ctx, fmt.Sprintf("Variable %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Value = types.StringValue(variable.Value)
+	if !data.Description.IsNull() || variable.Description != "" {
+		data.Description = types.StringValue(variable.Description)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceVariableResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Setting a variable that already exists overwrites it.
+	tflog.Debug(ctx, fmt.Sprintf("Updating variable %s of space %s", data.Key.ValueString(), data.SpaceID.ValueString()))
+
+	err := r.client.SetSpaceVariable(ctx, data.SpaceID.ValueString(), data.Key.ValueString(), data.Value.ValueString(), data.Description.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("update variable %s", data.Key.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceVariableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A variable whose space is already gone is as good as deleted.
+	err := r.client.DeleteSpaceVariable(ctx, data.SpaceID.ValueString(), data.Key.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("delete variable %s", data.Key.ValueString()), err)
+		return
+	}
+}
+
+func (r *SpaceVariableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	spaceID, key, ok := splitSpaceKeyID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form namespace/name/KEY, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), spaceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+func NewSpaceVariableResource() resource.Resource {
+	return &SpaceVariableResource{}
+}
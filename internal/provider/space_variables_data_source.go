@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpaceVariablesDataSource{}
+
+// SpaceVariablesDataSource defines the data source implementation.
+type SpaceVariablesDataSource struct {
+	client *hfclient.Client
+}
+
+// SpaceVariablesDataSourceModel describes the data source data model.
+type SpaceVariablesDataSourceModel struct {
+	SpaceID      types.String `tfsdk:"space_id"`
+	Variables    types.Map    `tfsdk:"variables"`
+	Descriptions types.Map    `tfsdk:"descriptions"`
+}
+
+func (d *SpaceVariablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_variables"
+}
+
+func (d *SpaceVariablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the variables of a space, so that other resources can share their values instead of repeating them.",
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+				Required:            true,
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The values of the variables of the space, by key.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"descriptions": schema.MapAttribute{
+				MarkdownDescription: "The descriptions of the variables of the space that have one, by key.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SpaceVariablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SpaceVariablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpaceVariablesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, err := d.client.ListSpaceVariables(ctx, data.SpaceID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list space variables", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Space %s has %d variables", data.SpaceID.ValueString(), len(variables)))
+
+	values := make(map[string]string, len(variables))
+	descriptions := make(map[string]string)
+	for key, variable := range variables {
+		values[key] = variable.Value
+		if variable.Description != "" {
+			descriptions[key] = variable.Description
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Variables, diags = types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	data.Descriptions, diags = types.MapValueFrom(ctx, types.StringType, descriptions)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpaceVariablesDataSource() datasource.DataSource {
+	return &SpaceVariablesDataSource{}
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpacesDataSource{}
+
+// spacesSortFields maps the sort orders of the spaces data source to the
+// fields the API sorts by.
+var spacesSortFields = map[string]string{
+	"likes":    "likes",
+	"modified": "lastModified",
+}
+
+// SpacesDataSource defines the data source implementation.
+type SpacesDataSource struct {
+	client *hfclient.Client
+}
+
+// SpacesDataSourceModel describes the data source data model.
+type SpacesDataSourceModel struct {
+	Author types.String       `tfsdk:"author"`
+	Search types.String       `tfsdk:"search"`
+	SDK    types.String       `tfsdk:"sdk"`
+	Sort   types.String       `tfsdk:"sort"`
+	Limit  types.Int64        `tfsdk:"limit"`
+	IDs    types.List         `tfsdk:"ids"`
+	Spaces []SpacesEntryModel `tfsdk:"spaces"`
+}
+
+// SpacesEntryModel describes a space listed by the data source.
+type SpacesEntryModel struct {
+	ID           types.String `tfsdk:"id"`
+	Author       types.String `tfsdk:"author"`
+	SDK          types.String `tfsdk:"sdk"`
+	Private      types.Bool   `tfsdk:"private"`
+	Likes        types.Int64  `tfsdk:"likes"`
+	LastModified types.String `tfsdk:"last_modified"`
+}
+
+func (d *SpacesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+func (d *SpacesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the spaces on the Hugging Face Hub matching some filters.",
+		Attributes: map[string]schema.Attribute{
+			"author": schema.StringAttribute{
+				MarkdownDescription: "Only list spaces owned by this user or organization.",
+				Optional:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "Only list spaces whose ID contains this term.",
+				Optional:            true,
+			},
+			"sdk": schema.StringAttribute{
+				MarkdownDescription: "Only list spaces using this SDK, such as `gradio` or `docker`.",
+				Optional:            true,
+			},
+			"sort": schema.StringAttribute{
+				MarkdownDescription: "List the most liked spaces first with `likes`, or the most recently modified ones with `modified`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("likes", "modified"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of spaces to list. Defaults to all of them.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the spaces, in the form `namespace/name`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"spaces": schema.ListNestedAttribute{
+				MarkdownDescription: "The spaces.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the space, in the form `namespace/name`.",
+							Computed:            true,
+						},
+						"author": schema.StringAttribute{
+							MarkdownDescription: "The user or organization owning the space.",
+							Computed:            true,
+						},
+						"sdk": schema.StringAttribute{
+							MarkdownDescription: "The SDK of the space.",
+							Computed:            true,
+						},
+						"private": schema.BoolAttribute{
+							MarkdownDescription: "Whether the space is private.",
+							Computed:            true,
+						},
+						"likes": schema.Int64Attribute{
+							MarkdownDescription: "The number of likes of the space.",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: "When the space was last modified.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SpacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SpacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpacesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaces, err := d.client.ListSpaces(ctx, hfclient.ListSpacesRequest{
+		Author: data.Author.ValueString(),
+		Search: data.Search.ValueString(),
+		SDK:    data.SDK.ValueString(),
+		Sort:   spacesSortFields[data.Sort.ValueString()],
+		Limit:  int(data.Limit.ValueInt64()),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list spaces", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d spaces", len(spaces)))
+
+	ids := make([]string, 0, len(spaces))
+	data.Spaces = make([]SpacesEntryModel, 0, len(spaces))
+	for _, space := range spaces {
+		if space.ID == nil {
+			continue
+		}
+
+		ids = append(ids, *space.ID)
+		data.Spaces = append(data.Spaces, SpacesEntryModel{
+			ID:           types.StringPointerValue(space.ID),
+			Author:       types.StringPointerValue(space.Author),
+			SDK:          types.StringPointerValue(space.SDK),
+			Private:      types.BoolPointerValue(space.Private),
+			Likes:        types.Int64PointerValue(space.Likes),
+			LastModified: types.StringPointerValue(space.LastModified),
+		})
+	}
+
+	idsValue, diags := stringListValue(ctx, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpacesDataSource() datasource.DataSource {
+	return &SpacesDataSource{}
+}
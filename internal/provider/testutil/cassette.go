@@ -0,0 +1,303 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordEnvVar is the environment variable that makes cassettes record the
+// interactions with the real Hub instead of replaying them.
+const RecordEnvVar = "HF_RECORD"
+
+// defaultUpstream is the Hub cassettes record from unless HF_ENDPOINT is set.
+const defaultUpstream = "https://huggingface.co"
+
+// cassetteDir is the directory cassettes are stored in, relative to the
+// package under test.
+const cassetteDir = "testdata/cassettes"
+
+// redactedResponseKeys lists the JSON keys whose values are redacted from
+// recorded responses, as they hold credentials.
+var redactedResponseKeys = map[string]bool{
+	"token":       true,
+	"accessToken": true,
+}
+
+// recordedURLRegexp matches the base URL of the cassette server a link was
+// recorded through.
+var recordedURLRegexp = regexp.MustCompile(`http://127\.0\.0\.1:[0-9]+`)
+
+// Interaction is a request to the Hub and the response it was answered
+// with. Request bodies are not recorded, as they hold the values of secrets.
+type Interaction struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Cassette records the interactions of the provider with the real Hub to a
+// file, or replays them from it so that tests run deterministically and
+// without credentials. It records when HF_RECORD is set, using the token from
+// HF_TOKEN, and replays otherwise.
+type Cassette struct {
+	t      testing.TB
+	path   string
+	record bool
+	server *httptest.Server
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewCassette starts a cassette named name that is saved, when recording, or
+// checked to be fully replayed, when replaying, once t completes.
+func NewCassette(t testing.TB, name string) *Cassette {
+	t.Helper()
+
+	c := &Cassette{
+		t:      t,
+		path:   filepath.Join(cassetteDir, name+".json"),
+		record: os.Getenv(RecordEnvVar) != "",
+	}
+
+	if c.record {
+		upstream := os.Getenv("HF_ENDPOINT")
+		if upstream == "" {
+			upstream = defaultUpstream
+		}
+		target, err := url.Parse(upstream)
+		if err != nil {
+			t.Fatalf("parsing HF_ENDPOINT: %s", err)
+		}
+
+		c.server = httptest.NewServer(c.recordHandler(target))
+	} else {
+		content, err := os.ReadFile(c.path)
+		if err != nil {
+			t.Fatalf("reading cassette %s, record it with %s=1: %s", c.path, RecordEnvVar, err)
+		}
+		if err := json.Unmarshal(content, &c.interactions); err != nil {
+			t.Fatalf("decoding cassette %s: %s", c.path, err)
+		}
+		c.used = make([]bool, len(c.interactions))
+
+		c.server = httptest.NewServer(http.HandlerFunc(c.replay))
+	}
+
+	t.Cleanup(func() {
+		c.server.Close()
+		c.finish()
+	})
+
+	return c
+}
+
+// URL returns the base URL of the cassette, to be used as the endpoint of
+// the provider.
+func (c *Cassette) URL() string {
+	return c.server.URL
+}
+
+// ProviderConfig returns a provider block pointing the provider at the
+// cassette. The token is taken from HF_TOKEN while recording.
+func (c *Cassette) ProviderConfig() string {
+	token := fmt.Sprintf("token       = %q\n  ", Token)
+	if c.record {
+		token = ""
+	}
+
+	return fmt.Sprintf(`
+provider "huggingface-spaces" {
+  %sendpoint    = %q
+  max_retries = 0
+}
+`, token, c.URL())
+}
+
+// Recording reports whether the cassette records from the real Hub.
+func (c *Cassette) Recording() bool {
+	return c.record
+}
+
+// recordHandler forwards requests to target and records their responses.
+func (c *Cassette) recordHandler(target *url.URL) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream := *target
+		upstream.Path = r.URL.Path
+		upstream.RawPath = r.URL.RawPath
+		upstream.RawQuery = r.URL.RawQuery
+
+		req, err := http.NewRequestWithContext(r.Context(), r.Method, upstream.String(), r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		req.Header = r.Header.Clone()
+
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+
+		// Pages of lists link to the next one, which must be requested
+		// through the cassette as well.
+		interaction := Interaction{
+			Method:      r.Method,
+			URL:         r.URL.RequestURI(),
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Link:        strings.ReplaceAll(resp.Header.Get("Link"), target.Scheme+"://"+target.Host, c.URL()),
+			Body:        string(redactResponse(body)),
+		}
+
+		c.mu.Lock()
+		c.interactions = append(c.interactions, interaction)
+		c.mu.Unlock()
+
+		writeInteraction(w, interaction, body)
+	})
+}
+
+// replay answers a request with the first recorded interaction with the
+// same method and URL that was not replayed yet. Terraform refreshes
+// resources a varying number of times, so once every GET of a URL was
+// replayed the last one is replayed again.
+func (c *Cassette) replay(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		if interaction.Method != r.Method || interaction.URL != r.URL.RequestURI() {
+			continue
+		}
+		last = i
+		if c.used[i] {
+			continue
+		}
+		c.used[i] = true
+
+		c.writeReplayed(w, interaction)
+		return
+	}
+
+	if last >= 0 && r.Method == http.MethodGet {
+		c.writeReplayed(w, c.interactions[last])
+		return
+	}
+
+	c.t.Errorf("cassette %s has no interaction left for %s %s, record it again with %s=1", c.path, r.Method, r.URL.RequestURI(), RecordEnvVar)
+	writeError(w, http.StatusNotImplemented, "No recorded interaction")
+}
+
+// writeReplayed answers with a replayed interaction. Links recorded from
+// another cassette server are pointed at this one.
+func (c *Cassette) writeReplayed(w http.ResponseWriter, interaction Interaction) {
+	if interaction.Link != "" {
+		interaction.Link = recordedURLRegexp.ReplaceAllString(interaction.Link, c.URL())
+	}
+
+	writeInteraction(w, interaction, []byte(interaction.Body))
+}
+
+// writeInteraction answers with the response of interaction and body.
+func writeInteraction(w http.ResponseWriter, interaction Interaction, body []byte) {
+	if interaction.ContentType != "" {
+		w.Header().Set("Content-Type", interaction.ContentType)
+	}
+	if interaction.Link != "" {
+		w.Header().Set("Link", interaction.Link)
+	}
+	w.WriteHeader(interaction.StatusCode)
+	_, _ = w.Write(body)
+}
+
+// finish saves the recorded interactions, or checks that every interaction
+// was replayed.
+func (c *Cassette) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.record {
+		for i, used := range c.used {
+			if !used {
+				c.t.Errorf("cassette %s: interaction %s %s was not replayed", c.path, c.interactions[i].Method, c.interactions[i].URL)
+			}
+		}
+		return
+	}
+
+	content, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		c.t.Errorf("encoding cassette %s: %s", c.path, err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		c.t.Errorf("creating cassette directory: %s", err)
+		return
+	}
+	if err := os.WriteFile(c.path, append(content, '\n'), 0o644); err != nil {
+		c.t.Errorf("writing cassette %s: %s", c.path, err)
+	}
+}
+
+// redactResponse replaces the values of redactedResponseKeys in a JSON body.
+// Other bodies are recorded as they are.
+func redactResponse(body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil || !redactJSON(value) {
+		return body
+	}
+
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactJSON redacts the values of redactedResponseKeys from a decoded JSON
+// value in place, reporting whether any was redacted.
+func redactJSON(value interface{}) bool {
+	redacted := false
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, ok := field.(string); ok && redactedResponseKeys[key] {
+				value[key] = "REDACTED"
+				redacted = true
+				continue
+			}
+			redacted = redactJSON(field) || redacted
+		}
+	case []interface{}:
+		for _, item := range value {
+			redacted = redactJSON(item) || redacted
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,849 @@
+// Package testutil provides a fake Hugging Face Hub to run the provider
+// against without touching the real API.
+package testutil
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Token is the API token the fake Hub accepts.
+const Token = "hf_testutil"
+
+// subdomainInvalidRegexp matches the characters of a space ID that are
+// replaced by dashes in its subdomain.
+var subdomainInvalidRegexp = regexp.MustCompile(`[^a-z0-9]`)
+
+// hardwareFlavors are the hardware flavors the fake Hub offers.
+var hardwareFlavors = []hardwareFlavor{
+	{Name: "cpu-basic", PrettyName: "CPU basic", UnitCostUSD: 0, UnitLabel: "hour"},
+	{Name: "cpu-upgrade", PrettyName: "CPU upgrade", UnitCostUSD: 0.03, UnitLabel: "hour"},
+	{Name: "t4-small", PrettyName: "Nvidia T4 - small", UnitCostUSD: 0.4, UnitLabel: "hour"},
+	{Name: "a10g-small", PrettyName: "Nvidia A10G - small", UnitCostUSD: 1, UnitLabel: "hour"},
+}
+
+// hardwareFlavor is a hardware flavor as listed by the API.
+type hardwareFlavor struct {
+	Name        string  `json:"name"`
+	PrettyName  string  `json:"prettyName"`
+	UnitCostUSD float64 `json:"unitCostUSD"`
+	UnitLabel   string  `json:"unitLabel"`
+}
+
+// Hub is a fake Hugging Face Hub serving the endpoints the space, model and
+// dataset resources use from memory. It is safe for concurrent use.
+type Hub struct {
+	// User is the name of the user the token belongs to, and the namespace
+	// of spaces created without one.
+	User string
+
+	server *httptest.Server
+
+	mu           sync.Mutex
+	spaces       map[string]*Space
+	repos        map[string]*Repo
+	requests     []Request
+	interceptors []Interceptor
+}
+
+// Request is a request the fake Hub received.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// Interceptor answers a request in place of the fake Hub, to inject failures
+// or responses the Hub does not give by itself. It returns false to let the
+// Hub answer the request.
+type Interceptor func(w http.ResponseWriter, r *http.Request) bool
+
+// Space is a space stored by the fake Hub.
+type Space struct {
+	ID           string
+	SDK          string
+	Private      bool
+	Pinned       bool
+	Hardware     string
+	Storage      string
+	Region       string
+	SleepTime    *int64
+	Stage        string
+	DevMode      bool
+	CustomDomain string
+	Gated        interface{}
+	Tags         []string
+	Models       []string
+	Datasets     []string
+	SHA          string
+	CreatedAt    time.Time
+	LastModified time.Time
+
+	Secrets   map[string]hfclient.SpaceSecret
+	Variables map[string]hfclient.SpaceVariable
+	Files     map[string][]byte
+
+	// RequestedHardware and RequestedStorage are reported as requested
+	// instead of Hardware and Storage when set, as while a change is in
+	// progress.
+	RequestedHardware string
+	RequestedStorage  string
+
+	// ImportedFrom is the URL of the Git repository last imported into the
+	// space.
+	ImportedFrom string
+}
+
+// NewHub starts a fake Hub that is shut down when t completes.
+func NewHub(t testing.TB) *Hub {
+	t.Helper()
+
+	h := &Hub{
+		User:   "testutil",
+		spaces: make(map[string]*Space),
+		repos:  make(map[string]*Repo),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/whoami-v2", h.whoami)
+	mux.HandleFunc("GET /api/spaces/hardware", h.listHardware)
+	mux.HandleFunc("POST /api/repos/create", h.createRepo)
+	mux.HandleFunc("DELETE /api/repos/delete", h.deleteRepo)
+	mux.HandleFunc("POST /api/repos/move", h.moveRepo)
+	mux.HandleFunc("GET /api/spaces/{namespace}/{name}", h.withSpace(h.getSpace))
+	mux.HandleFunc("GET /api/spaces/{namespace}/{name}/runtime", h.withSpace(h.getRuntime))
+	mux.HandleFunc("PUT /api/spaces/{namespace}/{name}/settings", h.withSpace(h.updateSettings))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/hardware", h.withSpace(h.setHardware))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/storage", h.withSpace(h.setStorage))
+	mux.HandleFunc("DELETE /api/spaces/{namespace}/{name}/storage", h.withSpace(h.deleteStorage))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/sleeptime", h.withSpace(h.setSleepTime))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/pause", h.withSpace(h.pause))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/restart", h.withSpace(h.restart))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/dev-mode", h.withSpace(h.setDevMode))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/import", h.withSpace(h.importGit))
+	mux.HandleFunc("GET /api/spaces/{namespace}/{name}/secrets", h.withSpace(h.listSecrets))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/secrets", h.withSpace(h.setSecret))
+	mux.HandleFunc("DELETE /api/spaces/{namespace}/{name}/secrets", h.withSpace(h.deleteSecret))
+	mux.HandleFunc("GET /api/spaces/{namespace}/{name}/variables", h.withSpace(h.listVariables))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/variables", h.withSpace(h.setVariable))
+	mux.HandleFunc("DELETE /api/spaces/{namespace}/{name}/variables", h.withSpace(h.deleteVariable))
+	mux.HandleFunc("POST /api/spaces/{namespace}/{name}/commit/{revision}", h.withSpace(h.commit))
+	mux.HandleFunc("GET /spaces/{namespace}/{name}/resolve/{revision}/{path...}", h.withSpace(h.download))
+	mux.HandleFunc("GET /api/models/{namespace}/{name}", h.withRepo(hfclient.RepoTypeModel, h.getModel))
+	mux.HandleFunc("PUT /api/models/{namespace}/{name}/settings", h.withRepo(hfclient.RepoTypeModel, h.updateRepoSettings))
+	mux.HandleFunc("POST /api/models/{namespace}/{name}/commit/{revision}", h.withRepo(hfclient.RepoTypeModel, h.commitRepo))
+	mux.HandleFunc("GET /api/datasets/{namespace}/{name}", h.withRepo(hfclient.RepoTypeDataset, h.getDataset))
+	mux.HandleFunc("PUT /api/datasets/{namespace}/{name}/settings", h.withRepo(hfclient.RepoTypeDataset, h.updateRepoSettings))
+	mux.HandleFunc("POST /api/datasets/{namespace}/{name}/commit/{revision}", h.withRepo(hfclient.RepoTypeDataset, h.commitRepo))
+	mux.HandleFunc("GET /datasets/{namespace}/{name}/resolve/{revision}/{path...}", h.withRepo(hfclient.RepoTypeDataset, h.downloadRepoFile))
+	mux.HandleFunc("GET /", h.downloadModelFile)
+
+	h.server = httptest.NewServer(h.record(h.authenticate(h.intercept(mux))))
+	t.Cleanup(h.server.Close)
+
+	return h
+}
+
+// URL returns the base URL of the fake Hub, to be used as the endpoint of
+// the provider.
+func (h *Hub) URL() string {
+	return h.server.URL
+}
+
+// ProviderConfig returns a provider block pointing the provider at the fake
+// Hub, with retries disabled so that failures surface right away.
+func (h *Hub) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "huggingface-spaces" {
+  token       = %q
+  endpoint    = %q
+  max_retries = 0
+}
+`, Token, h.URL())
+}
+
+// Space returns a copy of the space spaceID, or nil if it does not exist.
+func (h *Hub) Space(spaceID string) *Space {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	space, ok := h.spaces[spaceID]
+	if !ok {
+		return nil
+	}
+
+	copied := *space
+	copied.Secrets = copyMap(space.Secrets)
+	copied.Variables = copyMap(space.Variables)
+	copied.Files = copyMap(space.Files)
+	return &copied
+}
+
+// PutSpace stores space, replacing any space with the same ID, to set up
+// spaces that exist before Terraform runs or to simulate drift.
+func (h *Hub) PutSpace(space Space) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if space.Secrets == nil {
+		space.Secrets = make(map[string]hfclient.SpaceSecret)
+	}
+	if space.Variables == nil {
+		space.Variables = make(map[string]hfclient.SpaceVariable)
+	}
+	if space.Files == nil {
+		space.Files = make(map[string][]byte)
+	}
+	if space.Stage == "" {
+		space.Stage = "RUNNING"
+	}
+	space.touch()
+
+	h.spaces[space.ID] = &space
+}
+
+// DeleteSpace deletes the space spaceID, as if it was deleted outside of
+// Terraform.
+func (h *Hub) DeleteSpace(spaceID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.spaces, spaceID)
+}
+
+// Intercept makes interceptor look at every authenticated request before
+// the fake Hub answers it. Interceptors run in the order they were added.
+func (h *Hub) Intercept(interceptor Interceptor) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.interceptors = append(h.interceptors, interceptor)
+}
+
+// Requests returns the requests received so far with method and path, or all
+// of them if both are empty.
+func (h *Hub) Requests(method string, path string) []Request {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var requests []Request
+	for _, request := range h.requests {
+		if (method == "" || request.Method == method) && (path == "" || request.Path == path) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// record keeps every request, including those that are rejected.
+func (h *Hub) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		h.mu.Lock()
+		h.requests = append(h.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		h.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// intercept lets the interceptors answer a request before next does.
+func (h *Hub) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		interceptors := append([]Interceptor(nil), h.interceptors...)
+		h.mu.Unlock()
+
+		for _, interceptor := range interceptors {
+			if interceptor(w, r) {
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate rejects requests that do not carry Token.
+func (h *Hub) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "Invalid credentials in Authorization header")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withSpace looks up the space a request is about, answering with a 404 if
+// it does not exist. The Hub is locked while handler runs.
+func (h *Hub) withSpace(handler func(http.ResponseWriter, *http.Request, *Space)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		space, ok := h.spaces[r.PathValue("namespace")+"/"+r.PathValue("name")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+
+		handler(w, r, space)
+	}
+}
+
+func (h *Hub) whoami(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, hfclient.WhoAmI{
+		Type: "user",
+		Name: h.User,
+		Auth: hfclient.WhoAmIAuth{Type: "access_token"},
+	})
+}
+
+func (h *Hub) listHardware(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, hardwareFlavors)
+}
+
+func (h *Hub) createRepo(w http.ResponseWriter, r *http.Request) {
+	var in hfclient.CreateRepoRequest
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	namespace := in.Organization
+	if namespace == "" {
+		namespace = h.User
+	}
+	id := namespace + "/" + in.Name
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if in.Type != hfclient.RepoTypeSpace {
+		h.createModelOrDataset(w, in, id)
+		return
+	}
+
+	if _, ok := h.spaces[id]; ok {
+		writeError(w, http.StatusConflict, "You already created this space repo")
+		return
+	}
+
+	hardware := in.Hardware
+	if hardware == "" {
+		hardware = "cpu-basic"
+	}
+
+	now := time.Now().UTC()
+	space := &Space{
+		ID:        id,
+		SDK:       in.SDK,
+		Private:   in.Private != nil && *in.Private,
+		Hardware:  hardware,
+		Storage:   in.Storage,
+		SleepTime: in.SleepTime,
+		Stage:     "RUNNING",
+		Tags:      in.Tags,
+		CreatedAt: now,
+		Secrets:   make(map[string]hfclient.SpaceSecret),
+		Variables: make(map[string]hfclient.SpaceVariable),
+		Files: map[string][]byte{
+			"README.md": []byte(fmt.Sprintf("---\ntitle: %s\nsdk: %s\n---\n", in.Name, in.SDK)),
+		},
+	}
+	space.touch()
+	h.spaces[id] = space
+
+	writeJSON(w, hfclient.CreateRepoResponse{
+		Name: id,
+		URL:  h.URL() + "/spaces/" + id,
+	})
+}
+
+func (h *Hub) deleteRepo(w http.ResponseWriter, r *http.Request) {
+	var in hfclient.DeleteRepoRequest
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	id := in.Name
+	if in.Organization != "" {
+		id = in.Organization + "/" + in.Name
+	} else if !strings.Contains(id, "/") {
+		id = h.User + "/" + id
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if in.Type != hfclient.RepoTypeSpace {
+		if _, ok := h.repos[repoKey(in.Type, id)]; !ok {
+			writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+		delete(h.repos, repoKey(in.Type, id))
+		return
+	}
+
+	if _, ok := h.spaces[id]; !ok {
+		writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+	delete(h.spaces, id)
+}
+
+func (h *Hub) moveRepo(w http.ResponseWriter, r *http.Request) {
+	var in hfclient.MoveRepoRequest
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if in.Type != hfclient.RepoTypeSpace {
+		h.moveModelOrDataset(w, in)
+		return
+	}
+
+	space, ok := h.spaces[in.FromRepo]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+	if _, ok := h.spaces[in.ToRepo]; ok {
+		writeError(w, http.StatusConflict, "Repository already exists")
+		return
+	}
+
+	delete(h.spaces, in.FromRepo)
+	space.ID = in.ToRepo
+	h.spaces[in.ToRepo] = space
+}
+
+func (h *Hub) getSpace(w http.ResponseWriter, r *http.Request, space *Space) {
+	namespace, _, _ := strings.Cut(space.ID, "/")
+	subdomain := subdomainInvalidRegexp.ReplaceAllString(strings.ToLower(space.ID), "-")
+	host := "https://" + subdomain + ".hf.space"
+	createdAt := space.CreatedAt.Format(time.RFC3339)
+	lastModified := space.LastModified.Format(time.RFC3339)
+	var likes int64
+
+	out := hfclient.Space{
+		ID:           &space.ID,
+		Author:       &namespace,
+		SDK:          &space.SDK,
+		Private:      &space.Private,
+		Pinned:       &space.Pinned,
+		Likes:        &likes,
+		LastModified: &lastModified,
+		Subdomain:    &subdomain,
+		Host:         &host,
+		SHA:          &space.SHA,
+		CreatedAt:    &createdAt,
+		Tags:         space.Tags,
+		Models:       space.Models,
+		Datasets:     space.Datasets,
+		Runtime:      space.runtime(),
+		Gated:        false,
+	}
+	if space.CustomDomain != "" {
+		out.CustomDomain = &space.CustomDomain
+	}
+	if space.Gated != nil {
+		out.Gated = space.Gated
+	}
+
+	writeJSON(w, out)
+}
+
+func (h *Hub) getRuntime(w http.ResponseWriter, r *http.Request, space *Space) {
+	writeJSON(w, space.runtime())
+}
+
+func (h *Hub) updateSettings(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in hfclient.RepoSettings
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if in.Private != nil {
+		space.Private = *in.Private
+	}
+	if in.Gated != nil {
+		space.Gated = in.Gated
+	}
+	if in.Pinned != nil {
+		space.Pinned = *in.Pinned
+	}
+	if in.CustomDomain != nil {
+		space.CustomDomain = *in.CustomDomain
+	}
+	if in.Tags != nil {
+		space.Tags = *in.Tags
+	}
+	space.touch()
+}
+
+func (h *Hub) setHardware(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in struct {
+		Flavor string `json:"flavor"`
+		Region string `json:"region"`
+	}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	for _, flavor := range hardwareFlavors {
+		if flavor.Name == in.Flavor {
+			space.Hardware = in.Flavor
+			space.RequestedHardware = ""
+			space.Region = in.Region
+			space.Stage = "RUNNING"
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid hardware flavor %q", in.Flavor))
+}
+
+func (h *Hub) setStorage(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in struct {
+		Tier string `json:"tier"`
+	}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.Storage = in.Tier
+	space.RequestedStorage = ""
+}
+
+func (h *Hub) deleteStorage(w http.ResponseWriter, r *http.Request, space *Space) {
+	space.Storage = ""
+	space.RequestedStorage = ""
+}
+
+func (h *Hub) setSleepTime(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in struct {
+		Seconds int64 `json:"seconds"`
+	}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.SleepTime = &in.Seconds
+}
+
+func (h *Hub) pause(w http.ResponseWriter, r *http.Request, space *Space) {
+	space.Stage = "PAUSED"
+}
+
+func (h *Hub) restart(w http.ResponseWriter, r *http.Request, space *Space) {
+	space.Stage = "RUNNING"
+}
+
+func (h *Hub) setDevMode(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in struct {
+		Enabled bool `json:"enabled"`
+	}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.DevMode = in.Enabled
+}
+
+func (h *Hub) importGit(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in struct {
+		URL string `json:"url"`
+	}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.ImportedFrom = in.URL
+	space.touch()
+}
+
+func (h *Hub) listSecrets(w http.ResponseWriter, r *http.Request, space *Space) {
+	writeJSON(w, space.Secrets)
+}
+
+func (h *Hub) setSecret(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in keyValue
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.Secrets[in.Key] = hfclient.SpaceSecret{
+		Description: in.Description,
+		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func (h *Hub) deleteSecret(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in keyValue
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if _, ok := space.Secrets[in.Key]; !ok {
+		writeError(w, http.StatusNotFound, "Secret not found")
+		return
+	}
+	delete(space.Secrets, in.Key)
+}
+
+func (h *Hub) listVariables(w http.ResponseWriter, r *http.Request, space *Space) {
+	writeJSON(w, space.Variables)
+}
+
+func (h *Hub) setVariable(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in keyValue
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	space.Variables[in.Key] = hfclient.SpaceVariable{
+		Value:       in.Value,
+		Description: in.Description,
+		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func (h *Hub) deleteVariable(w http.ResponseWriter, r *http.Request, space *Space) {
+	var in keyValue
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if _, ok := space.Variables[in.Key]; !ok {
+		writeError(w, http.StatusNotFound, "Variable not found")
+		return
+	}
+	delete(space.Variables, in.Key)
+}
+
+// commit applies a newline delimited JSON commit to the files of a space.
+func (h *Hub) commit(w http.ResponseWriter, r *http.Request, space *Space) {
+	files, err := applyCommit(r.Body, space.Files)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	space.Files = files
+	space.Models, space.Datasets = linkedRepos(files["README.md"])
+	space.touch()
+
+	writeJSON(w, hfclient.CommitInfo{
+		CommitURL: h.URL() + "/spaces/" + space.ID + "/commit/" + space.SHA,
+		CommitOID: space.SHA,
+	})
+}
+
+// applyCommit returns files with the newline delimited JSON commit read from
+// body applied to them. files itself is left unchanged.
+func applyCommit(body io.Reader, files map[string][]byte) (map[string][]byte, error) {
+	files = copyMap(files)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		var line struct {
+			Key   string `json:"key"`
+			Value struct {
+				Path     string `json:"path"`
+				Content  string `json:"content"`
+				Encoding string `json:"encoding"`
+			} `json:"value"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("Invalid commit line: %s", err)
+		}
+
+		switch line.Key {
+		case "file":
+			content, err := base64.StdEncoding.DecodeString(line.Value.Content)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid content of %s: %s", line.Value.Path, err)
+			}
+			files[line.Value.Path] = content
+		case "deletedFile":
+			delete(files, line.Value.Path)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (h *Hub) download(w http.ResponseWriter, r *http.Request, space *Space) {
+	content, ok := space.Files[r.PathValue("path")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Entry not found")
+		return
+	}
+
+	_, _ = w.Write(content)
+}
+
+// touch records a change of the space, giving it a new commit SHA.
+func (s *Space) touch() {
+	s.LastModified = time.Now().UTC()
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = s.LastModified
+	}
+	s.SHA = commitSHA(s.SHA, s.LastModified, s.Files)
+}
+
+// commitSHA returns the SHA of a commit changing the repository at previous
+// to files at lastModified.
+func commitSHA(previous string, lastModified time.Time, files map[string][]byte) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s\n%s\n", previous, lastModified.Format(time.RFC3339Nano))
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(hash, "%s\n%s\n", path, files[path])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// runtime returns the runtime of the space. Requested hardware and storage
+// are available right away, unless RequestedHardware or RequestedStorage say
+// otherwise.
+func (s *Space) runtime() *hfclient.SpaceRuntime {
+	runtime := &hfclient.SpaceRuntime{
+		Stage:     s.Stage,
+		SleepTime: s.SleepTime,
+		DevMode:   &s.DevMode,
+	}
+	if s.Hardware != "" || s.RequestedHardware != "" {
+		runtime.Hardware = hfclient.SpaceHardware{
+			Current:   optionalString(s.Hardware),
+			Requested: optionalString(s.Hardware),
+		}
+		if s.RequestedHardware != "" {
+			runtime.Hardware.Requested = optionalString(s.RequestedHardware)
+		}
+	}
+	if s.Storage != "" || s.RequestedStorage != "" {
+		runtime.Storage = hfclient.SpaceStorage{
+			Current:   optionalString(s.Storage),
+			Requested: optionalString(s.Storage),
+		}
+		if s.RequestedStorage != "" {
+			runtime.Storage.Requested = optionalString(s.RequestedStorage)
+		}
+	}
+
+	return runtime
+}
+
+// linkedRepos returns the models and datasets listed in the frontmatter of
+// a card, from which the Hub derives those of a space.
+func linkedRepos(readme []byte) ([]string, []string) {
+	content := string(readme)
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, nil
+	}
+	frontmatter, _, found := strings.Cut(content[len("---\n"):], "\n---")
+	if !found {
+		return nil, nil
+	}
+
+	var metadata struct {
+		Models   []string `yaml:"models"`
+		Datasets []string `yaml:"datasets"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &metadata); err != nil {
+		return nil, nil
+	}
+
+	return metadata.Models, metadata.Datasets
+}
+
+// keyValue is the body of requests adding or deleting secrets and
+// variables.
+type keyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// readJSON decodes the body of r into in, answering with a 400 and
+// returning false if it is not valid.
+func readJSON(w http.ResponseWriter, r *http.Request, in interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// writeJSON answers with out encoded as JSON.
+func writeJSON(w http.ResponseWriter, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// writeError answers with statusCode and message in the format of the API.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// optionalString returns a pointer to value, or nil if it is empty.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// copyMap returns a shallow copy of m.
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	copied := make(map[K]V, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,215 @@
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Repo is a model or dataset stored by the fake Hub.
+type Repo struct {
+	Type         hfclient.RepoType
+	ID           string
+	Private      bool
+	Gated        interface{}
+	SHA          string
+	LastModified time.Time
+
+	Files map[string][]byte
+}
+
+// repoKey returns the key of the repository repoID of repoType in the
+// repositories of the Hub.
+func repoKey(repoType hfclient.RepoType, repoID string) string {
+	return string(repoType) + ":" + repoID
+}
+
+// Repo returns a copy of the model or dataset repoID, or nil if it does not
+// exist.
+func (h *Hub) Repo(repoType hfclient.RepoType, repoID string) *Repo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	repo, ok := h.repos[repoKey(repoType, repoID)]
+	if !ok {
+		return nil
+	}
+
+	copied := *repo
+	copied.Files = copyMap(repo.Files)
+	return &copied
+}
+
+// PutRepo stores repo, replacing any repository of the same type with the
+// same ID, to set up repositories that exist before Terraform runs or to
+// simulate drift.
+func (h *Hub) PutRepo(repo Repo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if repo.Files == nil {
+		repo.Files = make(map[string][]byte)
+	}
+	repo.touch()
+
+	h.repos[repoKey(repo.Type, repo.ID)] = &repo
+}
+
+// withRepo looks up the repository of repoType named by the path of the
+// request for handler, answering 404 if it does not exist. The Hub is locked
+// while handler runs.
+func (h *Hub) withRepo(repoType hfclient.RepoType, handler func(http.ResponseWriter, *http.Request, *Repo)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		repo, ok := h.repos[repoKey(repoType, r.PathValue("namespace")+"/"+r.PathValue("name"))]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+
+		handler(w, r, repo)
+	}
+}
+
+// createModelOrDataset creates the model or dataset id for in. The Hub is
+// locked by the caller.
+func (h *Hub) createModelOrDataset(w http.ResponseWriter, in hfclient.CreateRepoRequest, id string) {
+	key := repoKey(in.Type, id)
+	if _, ok := h.repos[key]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("You already created this %s repo", in.Type))
+		return
+	}
+
+	repo := &Repo{
+		Type:    in.Type,
+		ID:      id,
+		Private: in.Private != nil && *in.Private,
+		Gated:   false,
+		Files:   make(map[string][]byte),
+	}
+	if in.License != "" {
+		repo.Files["README.md"] = []byte(fmt.Sprintf("---\nlicense: %s\n---\n", in.License))
+	}
+	repo.touch()
+	h.repos[key] = repo
+
+	url := h.URL() + "/" + id
+	if in.Type == hfclient.RepoTypeDataset {
+		url = h.URL() + "/datasets/" + id
+	}
+	writeJSON(w, hfclient.CreateRepoResponse{Name: id, URL: url})
+}
+
+// moveModelOrDataset renames or moves a model or dataset for in. The Hub is
+// locked by the caller.
+func (h *Hub) moveModelOrDataset(w http.ResponseWriter, in hfclient.MoveRepoRequest) {
+	repo, ok := h.repos[repoKey(in.Type, in.FromRepo)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+	if _, ok := h.repos[repoKey(in.Type, in.ToRepo)]; ok {
+		writeError(w, http.StatusConflict, "Repository already exists")
+		return
+	}
+
+	delete(h.repos, repoKey(in.Type, in.FromRepo))
+	repo.ID = in.ToRepo
+	h.repos[repoKey(in.Type, in.ToRepo)] = repo
+}
+
+func (h *Hub) getModel(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	author, _, _ := strings.Cut(repo.ID, "/")
+	lastModified := repo.LastModified.Format(time.RFC3339)
+
+	writeJSON(w, hfclient.Model{
+		ID:           &repo.ID,
+		Author:       &author,
+		Private:      &repo.Private,
+		SHA:          &repo.SHA,
+		LastModified: &lastModified,
+		Gated:        repo.Gated,
+	})
+}
+
+func (h *Hub) getDataset(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	lastModified := repo.LastModified.Format(time.RFC3339)
+
+	writeJSON(w, hfclient.Dataset{
+		ID:           &repo.ID,
+		Private:      &repo.Private,
+		SHA:          &repo.SHA,
+		LastModified: &lastModified,
+		Gated:        repo.Gated,
+	})
+}
+
+func (h *Hub) updateRepoSettings(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	var in hfclient.RepoSettings
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if in.Private != nil {
+		repo.Private = *in.Private
+	}
+	if in.Gated != nil {
+		repo.Gated = in.Gated
+	}
+	repo.touch()
+}
+
+// commitRepo applies a newline delimited JSON commit to the files of a model
+// or dataset.
+func (h *Hub) commitRepo(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	files, err := applyCommit(r.Body, repo.Files)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repo.Files = files
+	repo.touch()
+
+	writeJSON(w, hfclient.CommitInfo{
+		CommitURL: h.URL() + "/" + repo.ID + "/commit/" + repo.SHA,
+		CommitOID: repo.SHA,
+	})
+}
+
+func (h *Hub) downloadRepoFile(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	content, ok := repo.Files[r.PathValue("path")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Entry not found")
+		return
+	}
+
+	_, _ = w.Write(content)
+}
+
+// downloadModelFile serves the files of models, which are served from the
+// root of the Hub. It is registered for every GET request no other route
+// matches, as a pattern for the root would conflict with the API routes.
+func (h *Hub) downloadModelFile(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 5)
+	if len(parts) != 5 || parts[2] != "resolve" {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	r.SetPathValue("namespace", parts[0])
+	r.SetPathValue("name", parts[1])
+	r.SetPathValue("path", parts[4])
+	h.withRepo(hfclient.RepoTypeModel, h.downloadRepoFile)(w, r)
+}
+
+// touch records a change of the repository, giving it a new commit SHA.
+func (r *Repo) touch() {
+	r.LastModified = time.Now().UTC()
+	r.SHA = commitSHA(r.SHA, r.LastModified, r.Files)
+}
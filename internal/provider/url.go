@@ -0,0 +1,58 @@
+package provider
+
+import "strings"
+
+// defaultEndpoint is the base URL of the Hugging Face Hub API.
+const defaultEndpoint = "https://huggingface.co"
+
+// defaultInferenceEndpointsEndpoint is the base URL of the Inference Endpoints
+// API.
+const defaultInferenceEndpointsEndpoint = "https://api.endpoints.huggingface.cloud"
+
+// splitRepoID splits a repository ID of the form namespace/name. The
+// namespace is empty for IDs without a slash.
+func splitRepoID(id string) (string, string) {
+	namespace, name, found := strings.Cut(id, "/")
+	if !found {
+		return "", id
+	}
+	return namespace, name
+}
+
+// spaceKeyID builds the ID of a secret or variable of a space, of the form
+// namespace/name/KEY.
+func spaceKeyID(spaceID string, key string) string {
+	return spaceID + "/" + key
+}
+
+// splitSpaceKeyID splits an ID built by spaceKeyID into the space ID and the
+// key, reporting whether the ID is well formed.
+func splitSpaceKeyID(id string) (string, string, bool) {
+	i := strings.LastIndex(id, "/")
+	if i <= 0 || i == len(id)-1 {
+		return "", "", false
+	}
+	return id[:i], id[i+1:], true
+}
+
+// repoRefID builds the ID of a branch or tag of a repository, of the form
+// type:namespace/name@ref.
+func repoRefID(repoType string, repoID string, ref string) string {
+	return repoType + ":" + repoID + "@" + ref
+}
+
+// splitRepoRefID splits an ID built by repoRefID into the repository type,
+// the repository ID and the ref, reporting whether the ID is well formed.
+func splitRepoRefID(id string) (string, string, string, bool) {
+	repoType, rest, found := strings.Cut(id, ":")
+	if !found {
+		return "", "", "", false
+	}
+
+	repoID, ref, found := strings.Cut(rest, "@")
+	if !found || !strings.Contains(repoID, "/") || ref == "" {
+		return "", "", "", false
+	}
+
+	return repoType, repoID, ref, true
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &UserDataSource{}
+
+// UserDataSource defines the data source implementation.
+type UserDataSource struct {
+	client *hfclient.Client
+}
+
+// UserDataSourceModel describes the data source data model.
+type UserDataSourceModel struct {
+	Username     types.String `tfsdk:"username"`
+	FullName     types.String `tfsdk:"fullname"`
+	IsPro        types.Bool   `tfsdk:"is_pro"`
+	Orgs         types.List   `tfsdk:"orgs"`
+	Spaces       types.List   `tfsdk:"spaces"`
+	Models       types.List   `tfsdk:"models"`
+	NumDatasets  types.Int64  `tfsdk:"num_datasets"`
+	NumFollowers types.Int64  `tfsdk:"num_followers"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up the public profile of a user on the Hugging Face Hub. Reading a user that does not exist fails, which makes it suitable to check that a user exists before granting them access.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The name of the user, which is the namespace of the repositories they own.",
+				Required:            true,
+			},
+			"fullname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user.",
+				Computed:            true,
+			},
+			"is_pro": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is subscribed to the PRO plan.",
+				Computed:            true,
+			},
+			"orgs": schema.ListAttribute{
+				MarkdownDescription: "Names of the organizations the user is a public member of.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"spaces": schema.ListAttribute{
+				MarkdownDescription: "IDs of the spaces of the user the token can see, in the form `namespace/name`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"models": schema.ListAttribute{
+				MarkdownDescription: "IDs of the models of the user the token can see, in the form `namespace/name`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"num_datasets": schema.Int64Attribute{
+				MarkdownDescription: "The number of public datasets of the user.",
+				Computed:            true,
+			},
+			"num_followers": schema.Int64Attribute{
+				MarkdownDescription: "The number of followers of the user.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := data.Username.ValueString()
+
+	user, err := d.client.GetUser(ctx, username)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("read user %s", username), err)
+		return
+	}
+
+	// The overview only counts repositories, so they are listed by author.
+	spaces, err := d.client.ListSpaces(ctx, hfclient.ListSpacesRequest{Author: username})
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list spaces of %s", username), err)
+		return
+	}
+
+	models, err := d.client.ListModels(ctx, hfclient.ListModelsRequest{Author: username})
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("list models of %s", username), err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read user %s with %d spaces and %d models", username, len(spaces), len(models)))
+
+	var orgs, spaceIDs, modelIDs []string
+	for _, org := range user.Orgs {
+		orgs = append(orgs, org.Name)
+	}
+	for _, space := range spaces {
+		if space.ID != nil {
+			spaceIDs = append(spaceIDs, *space.ID)
+		}
+	}
+	for _, model := range models {
+		if model.ID != nil {
+			modelIDs = append(modelIDs, *model.ID)
+		}
+	}
+
+	data.FullName = types.StringValue(user.FullName)
+	data.IsPro = types.BoolValue(user.IsPro)
+	data.NumDatasets = types.Int64Value(user.NumDatasets)
+	data.NumFollowers = types.Int64Value(user.NumFollowers)
+
+	orgsValue, diags := stringListValue(ctx, orgs)
+	resp.Diagnostics.Append(diags...)
+	spacesValue, diags := stringListValue(ctx, spaceIDs)
+	resp.Diagnostics.Append(diags...)
+	modelsValue, diags := stringListValue(ctx, modelIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Orgs = orgsValue
+	data.Spaces = spacesValue
+	data.Models = modelsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &WebhookResource{}
+	_ resource.ResourceWithConfigure   = &WebhookResource{}
+	_ resource.ResourceWithImportState = &WebhookResource{}
+)
+
+// webhookDomains lists the kinds of events a webhook can subscribe to.
+var webhookDomains = []string{"repo", "discussion"}
+
+// webhookWatchedTypes lists the types of entities a webhook can watch.
+var webhookWatchedTypes = []string{"model", "dataset", "space", "user", "org"}
+
+// WebhookResource defines the resource implementation.
+type WebhookResource struct {
+	client *hfclient.Client
+}
+
+// WebhookResourceModel describes the resource data model.
+type WebhookResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	URL      types.String `tfsdk:"url"`
+	Watched  types.Set    `tfsdk:"watched"`
+	Domains  types.Set    `tfsdk:"domains"`
+	Secret   types.String `tfsdk:"secret"`
+	Disabled types.Bool   `tfsdk:"disabled"`
+}
+
+// WebhookWatchedModel describes an entity watched by a webhook.
+type WebhookWatchedModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a webhook that is called on events of repositories, users or organizations.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the webhook.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL events are sent to.",
+				Required:            true,
+			},
+			"watched": schema.SetNestedAttribute{
+				MarkdownDescription: "The repositories, users and organizations whose events are sent.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the entity, one of `model`, `dataset`, `space`, `user` or `org`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(webhookWatchedTypes...),
+							},
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the user or organization, or the ID of the repository.",
+							Required:            true,
+						},
+					},
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "The kinds of events that are sent, `repo` for changes to repositories and `discussion` for discussions and pull requests.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(webhookDomains...)),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "A secret sent in the `X-Webhook-Secret` header of every event, so that the receiver can verify its origin.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether events are no longer sent. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := webhookRequest(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.client.CreateWebhook(ctx, in)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create webhook", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created webhook %s", webhook.ID))
+
+	data.ID = types.StringValue(webhook.ID)
+
+	// Webhooks are created enabled.
+	if data.Disabled.ValueBool() {
+		if err := r.client.SetWebhookDisabled(ctx, webhook.ID, true); err != nil {
+			addClientError(&resp.Diagnostics, "disable webhook", err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.client.GetWebhook(ctx, data.ID.ValueString())
+	if hfclient.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Webhook %s not found, removing it from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read webhook", err)
+		return
+	}
+
+	watched := make([]WebhookWatchedModel, 0, len(webhook.Watched))
+	for _, entity := range webhook.Watched {
+		watched = append(watched, WebhookWatchedModel{
+			Type: types.StringValue(entity.Type),
+			Name: types.StringValue(entity.Name),
+		})
+	}
+
+	watchedValue, diags := types.SetValueFrom(ctx, data.Watched.ElementType(ctx), watched)
+	resp.Diagnostics.Append(diags...)
+	domainsValue, diags := types.SetValueFrom(ctx, types.StringType, webhook.Domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.URL = types.StringValue(webhook.URL)
+	data.Watched = watchedValue
+	data.Domains = domainsValue
+	data.Disabled = types.BoolValue(webhook.Disabled)
+
+	// The secret is kept as configured unless the API returns it.
+	if webhook.Secret != nil && (*webhook.Secret != "" || !data.Secret.IsNull()) {
+		data.Secret = types.StringValue(*webhook.Secret)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := webhookRequest(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.UpdateWebhook(ctx, data.ID.ValueString(), in); err != nil {
+		addClientError(&resp.Diagnostics, "update webhook", err)
+		return
+	}
+
+	if data.Disabled.ValueBool() != state.Disabled.ValueBool() {
+		if err := r.client.SetWebhookDisabled(ctx, data.ID.ValueString(), data.Disabled.ValueBool()); err != nil {
+			addClientError(&resp.Diagnostics, "update webhook disabled flag", err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteWebhook(ctx, data.ID.ValueString())
+	if err != nil && !hfclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete webhook", err)
+		return
+	}
+}
+
+func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// webhookRequest builds the body of a webhook create or update request from
+// data.
+func webhookRequest(ctx context.Context, data *WebhookResourceModel, diags *diag.Diagnostics) hfclient.Webhook {
+	in := hfclient.Webhook{
+		URL:    data.URL.ValueString(),
+		Secret: data.Secret.ValueStringPointer(),
+	}
+
+	var watched []WebhookWatchedModel
+	diags.Append(data.Watched.ElementsAs(ctx, &watched, false)...)
+	diags.Append(data.Domains.ElementsAs(ctx, &in.Domains, false)...)
+
+	for _, entity := range watched {
+		in.Watched = append(in.Watched, hfclient.WebhookWatched{
+			Type: entity.Type.ValueString(),
+			Name: entity.Name.ValueString(),
+		})
+	}
+
+	return in
+}
+
+func NewWebhookResource() resource.Resource {
+	return &WebhookResource{}
+}
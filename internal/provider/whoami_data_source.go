@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/strickvl/terraform-provider-huggingface-spaces/internal/hfclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &WhoAmIDataSource{}
+
+// WhoAmIDataSource defines the data source implementation.
+type WhoAmIDataSource struct {
+	client *hfclient.Client
+}
+
+// WhoAmIDataSourceModel describes the data source data model.
+type WhoAmIDataSourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	FullName  types.String `tfsdk:"fullname"`
+	Plan      types.String `tfsdk:"plan"`
+	Orgs      types.List   `tfsdk:"orgs"`
+	TokenRole types.String `tfsdk:"token_role"`
+	Scopes    types.List   `tfsdk:"scopes"`
+}
+
+func (d *WhoAmIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+func (d *WhoAmIDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the user or organization the provider token belongs to.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the user or organization, which is the namespace of the spaces it owns.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Either `user` or `org`.",
+				Computed:            true,
+			},
+			"fullname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user or organization.",
+				Computed:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "The plan of the user or organization, such as `free` or `pro`.",
+				Computed:            true,
+			},
+			"orgs": schema.ListAttribute{
+				MarkdownDescription: "Names of the organizations the user is a member of.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"token_role": schema.StringAttribute{
+				MarkdownDescription: "The role of the token, `read` or `write` for classic tokens and `fineGrained` otherwise.",
+				Computed:            true,
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token. Permissions limited to a single user, organization or repository are suffixed with `@` and its name.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *WhoAmIDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *WhoAmIDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WhoAmIDataSourceModel
+
+	whoami, err := d.client.WhoAmI(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read authenticated user", err)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Authenticated as %s %s", whoami.Type, whoami.Name))
+
+	data.Name = types.StringValue(whoami.Name)
+	data.Type = types.StringValue(whoami.Type)
+	data.FullName = types.StringValue(whoami.FullName)
+
+	// Users only report whether they are on the pro plan.
+	switch {
+	case whoami.Plan != nil:
+		data.Plan = types.StringValue(*whoami.Plan)
+	case whoami.IsPro:
+		data.Plan = types.StringValue("pro")
+	default:
+		data.Plan = types.StringValue("free")
+	}
+
+	var orgs []string
+	for _, org := range whoami.Orgs {
+		orgs = append(orgs, org.Name)
+	}
+
+	var scopes []string
+	data.TokenRole = types.StringNull()
+	if token := whoami.Auth.AccessToken; token != nil {
+		data.TokenRole = types.StringValue(token.Role)
+
+		if token.FineGrained != nil {
+			scopes = append(scopes, token.FineGrained.Global...)
+			for _, scoped := range token.FineGrained.Scoped {
+				for _, permission := range scoped.Permissions {
+					scopes = append(scopes, fmt.Sprintf("%s@%s", permission, scoped.Entity.Name))
+				}
+			}
+		}
+	}
+
+	orgsValue, diags := stringListValue(ctx, orgs)
+	resp.Diagnostics.Append(diags...)
+	scopesValue, diags := stringListValue(ctx, scopes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Orgs = orgsValue
+	data.Scopes = scopesValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewWhoAmIDataSource() datasource.DataSource {
+	return &WhoAmIDataSource{}
+}
@@ -0,0 +1,28 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWhoAmIDataSource(t *testing.T) {
+	testAccCassette(t, "whoami_data_source")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "huggingface-spaces_whoami" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.huggingface-spaces_whoami.test", "name", "tf-tester"),
+					resource.TestCheckResourceAttr("data.huggingface-spaces_whoami.test", "type", "user"),
+					resource.TestCheckResourceAttr("data.huggingface-spaces_whoami.test", "orgs.#", "1"),
+					resource.TestCheckResourceAttr("data.huggingface-spaces_whoami.test", "orgs.0", "tf-tester-org"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWhoAmIDataSource(t *testing.T) {
+	state, diags := newCassetteProvider(t, "whoami_data_source").readDataSource("huggingface-spaces_whoami", nil)
+	requireNoErrors(t, "read", diags)
+
+	expected := map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "tf-tester"),
+		"type":       tftypes.NewValue(tftypes.String, "user"),
+		"plan":       tftypes.NewValue(tftypes.String, "free"),
+		"token_role": tftypes.NewValue(tftypes.String, "fineGrained"),
+		"orgs": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "tf-tester-org"),
+		}),
+		"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "discussion.write"),
+			tftypes.NewValue(tftypes.String, "repo.content.read@tf-tester-org"),
+			tftypes.NewValue(tftypes.String, "repo.write@tf-tester-org"),
+		}),
+	}
+	for name, value := range expected {
+		if actual := attributeValue(t, state, name); !actual.Equal(value) {
+			t.Errorf("%s = %s, expected %s", name, actual, value)
+		}
+	}
+}